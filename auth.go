@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+	"golang.org/x/term"
+)
+
+const (
+	// authCommand is the name of the auth subcommand.
+	authCommand = "auth"
+	// authSetCommand is the name of the auth command storing an API key.
+	authSetCommand = "set"
+	// authGetCommand is the name of the auth command printing a stored API key.
+	authGetCommand = "get"
+	// keyringService is the service name under which the API keys are stored in the OS keychain,
+	// with the backend as the user name.
+	keyringService = "purlinfo"
+)
+
+// tokenKeyring stores the API keys of the backends.
+type tokenKeyring interface {
+	// Get returns the API key of the backend, or an error wrapping keyring.ErrNotFound if there is none.
+	Get(backend string) (string, error)
+	// Set stores the API key of the backend.
+	Set(backend, key string) error
+}
+
+// osKeyring is the tokenKeyring storing the API keys in the OS keychain
+// (macOS Keychain, Secret Service on Linux, Windows Credential Manager).
+type osKeyring struct{}
+
+var _ tokenKeyring = osKeyring{}
+
+// Get returns the API key of the backend from the OS keychain.
+func (osKeyring) Get(backend string) (string, error) {
+	return keyring.Get(keyringService, backend)
+}
+
+// Set stores the API key of the backend in the OS keychain.
+func (osKeyring) Set(backend, key string) error {
+	return keyring.Set(keyringService, backend, key)
+}
+
+// keyringToken returns the API key of the backend stored in the keyring, or an empty string if there is none.
+//
+// Errors are only logged: a keychain that is missing (e.g., no Secret Service on a headless Linux host)
+// or locked must not prevent lookups that do not need a token.
+func keyringToken(tokens tokenKeyring, logger *slog.Logger, backend string) string {
+	token, err := tokens.Get(backend)
+	if err != nil {
+		if !errors.Is(err, keyring.ErrNotFound) {
+			logger.Debug("failed to read the API key from the keyring", "backend", backend, "error", err)
+		}
+		return ""
+	}
+	return token
+}
+
+// isKnownBackend reports whether name is the name of a backend.
+func isKnownBackend(name string) bool {
+	return slices.Contains(backendNames(), name)
+}
+
+// readAPIKey reads an API key from the first line of stdin.
+//
+// If stdin is a terminal, the key is prompted for on stderr and read without echo, so that it does not
+// end up on screen or, unlike -key, in the shell history.
+func readAPIKey(stdin io.Reader, stderr io.Writer) (string, error) {
+	if f, ok := stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		fmt.Fprint(stderr, "API key: ")
+		key, err := term.ReadPassword(int(f.Fd()))
+		fmt.Fprintln(stderr)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(key)), nil
+	}
+
+	line, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// runAuth runs the auth subcommand with its arguments.
+//
+// auth set stores the API key of a backend in cfg.Keyring, which setupService then uses when neither
+// -token nor $PURLINFO_TOKEN is set. auth get prints it.
+func runAuth(cfg RunConfig, args []string) int {
+	command := ""
+	if len(args) > 0 {
+		command = args[0]
+		args = args[1:]
+	}
+
+	flags := flag.NewFlagSet(authCommand+" "+command, flag.ContinueOnError)
+	flags.SetOutput(cfg.Stderr)
+	backend := flags.String("service", "", "Backend the API key is for (e.g., github-packages) (required)")
+	var key *string
+	if command == authSetCommand {
+		key = flags.String("key", "", "API key to store (read from stdin if not set)")
+	}
+	flags.Usage = func() {
+		fmt.Fprintf(cfg.Stderr, "Usage: %s auth set -service backend [-key api-key]\n", os.Args[0])
+		fmt.Fprintf(cfg.Stderr, "       %s auth get -service backend\n\n", os.Args[0])
+		fmt.Fprintf(cfg.Stderr, "Store or print the API key of a backend in the OS keychain.\n\n")
+		fmt.Fprintf(cfg.Stderr, "Auth options:\n")
+		flags.PrintDefaults()
+	}
+
+	if command != authSetCommand && command != authGetCommand {
+		fmt.Fprintf(cfg.Stderr, "Error: Expected the auth command %s or %s, got %q\n\n",
+			authSetCommand, authGetCommand, command)
+		flags.Usage()
+		return exitInvalidArgs
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitInvalidArgs
+	}
+	if flags.NArg() != 0 {
+		fmt.Fprintf(cfg.Stderr, "Error: Unexpected arguments: %v\n\n", flags.Args())
+		flags.Usage()
+		return exitInvalidArgs
+	}
+	if !isKnownBackend(*backend) {
+		fmt.Fprintf(cfg.Stderr, "Error: -service must be a backend, got %q\n\n", *backend)
+		flags.Usage()
+		return exitInvalidArgs
+	}
+
+	if command == authGetCommand {
+		token, err := cfg.Keyring.Get(*backend)
+		if err != nil {
+			return commandError(cfg, "Failed to read the API key of "+*backend, err)
+		}
+		fmt.Fprintln(cfg.Stdout, token)
+		return exitSuccess
+	}
+
+	if *key == "" {
+		var err error
+		if *key, err = readAPIKey(cfg.Stdin, cfg.Stderr); err != nil {
+			return commandError(cfg, "Failed to read the API key", err)
+		}
+	}
+	if *key == "" {
+		fmt.Fprintf(cfg.Stderr, "Error: -key or an API key on stdin is required\n\n")
+		flags.Usage()
+		return exitInvalidArgs
+	}
+	if err := cfg.Keyring.Set(*backend, *key); err != nil {
+		return commandError(cfg, "Failed to store the API key of "+*backend, err)
+	}
+	cfg.Logger.Debug("stored API key", "backend", *backend)
+
+	return exitSuccess
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+// TestRunAuth tests the runAuth function.
+func TestRunAuth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		keyring    *fakeKeyring
+		args       []string
+		stdin      string
+		want       int
+		wantStdout string
+		wantTokens map[string]string
+	}{
+		{
+			name:       "set",
+			keyring:    &fakeKeyring{},
+			args:       []string{"set", "-service", backendGitHubPackages, "-key", "secret"},
+			want:       exitSuccess,
+			wantTokens: map[string]string{backendGitHubPackages: "secret"},
+		},
+		{
+			name:       "set replaces the key",
+			keyring:    &fakeKeyring{tokens: map[string]string{backendGitHubPackages: "old"}},
+			args:       []string{"set", "-service", backendGitHubPackages, "-key", "new"},
+			want:       exitSuccess,
+			wantTokens: map[string]string{backendGitHubPackages: "new"},
+		},
+		{
+			name:       "get",
+			keyring:    &fakeKeyring{tokens: map[string]string{backendGitHubPackages: "secret"}},
+			args:       []string{"get", "-service", backendGitHubPackages},
+			want:       exitSuccess,
+			wantStdout: "secret\n",
+		},
+		{
+			name:    "get without stored key",
+			keyring: &fakeKeyring{},
+			args:    []string{"get", "-service", backendGitHubPackages},
+			want:    exitRuntimeError,
+		},
+		{
+			name:    "keyring unavailable",
+			keyring: &fakeKeyring{err: keyring.ErrUnsupportedPlatform},
+			args:    []string{"set", "-service", backendGitHubPackages, "-key", "secret"},
+			want:    exitRuntimeError,
+		},
+		{
+			name:       "set with the key from stdin",
+			keyring:    &fakeKeyring{},
+			args:       []string{"set", "-service", backendGitHubPackages},
+			stdin:      "secret\n",
+			want:       exitSuccess,
+			wantTokens: map[string]string{backendGitHubPackages: "secret"},
+		},
+		{
+			name:    "set without key",
+			keyring: &fakeKeyring{},
+			args:    []string{"set", "-service", backendGitHubPackages},
+			want:    exitInvalidArgs,
+		},
+		{
+			name:    "get does not take a key",
+			keyring: &fakeKeyring{},
+			args:    []string{"get", "-service", backendGitHubPackages, "-key", "secret"},
+			want:    exitInvalidArgs,
+		},
+		{
+			name:    "unknown backend",
+			keyring: &fakeKeyring{},
+			args:    []string{"set", "-service", "unknown", "-key", "secret"},
+			want:    exitInvalidArgs,
+		},
+		{
+			name:    "missing service",
+			keyring: &fakeKeyring{},
+			args:    []string{"get"},
+			want:    exitInvalidArgs,
+		},
+		{
+			name:    "unknown command",
+			keyring: &fakeKeyring{},
+			args:    []string{"delete", "-service", backendGitHubPackages},
+			want:    exitInvalidArgs,
+		},
+		{
+			name:    "missing command",
+			keyring: &fakeKeyring{},
+			want:    exitInvalidArgs,
+		},
+		{
+			name:    "unexpected argument",
+			keyring: &fakeKeyring{},
+			args:    []string{"get", "-service", backendGitHubPackages, "extra"},
+			want:    exitInvalidArgs,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, stdout, stderr := newTestRunConfig(false, formatText)
+			cfg.Keyring = tt.keyring
+			cfg.Stdin = strings.NewReader(tt.stdin)
+
+			exitCode := runAuth(cfg, tt.args)
+
+			if exitCode != tt.want {
+				t.Errorf("runAuth() = %d, want %d\nStderr: %s", exitCode, tt.want, stderr.String())
+			}
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("runAuth() output = %q, want %q", stdout.String(), tt.wantStdout)
+			}
+			if tt.want != exitSuccess && stderr.Len() == 0 {
+				t.Errorf("runAuth() stderr is empty, want an error message")
+			}
+			for backend, want := range tt.wantTokens {
+				if got := tt.keyring.tokens[backend]; got != want {
+					t.Errorf("stored key of %s = %q, want %q", backend, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestKeyringToken tests the keyringToken function.
+func TestKeyringToken(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		keyring *fakeKeyring
+		want    string
+	}{
+		{
+			name:    "stored key",
+			keyring: &fakeKeyring{tokens: map[string]string{backendEcosystems: "secret"}},
+			want:    "secret",
+		},
+		{
+			name:    "no stored key",
+			keyring: &fakeKeyring{},
+		},
+		{
+			name:    "keyring unavailable",
+			keyring: &fakeKeyring{err: keyring.ErrUnsupportedPlatform},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, _, _ := newTestRunConfig(false, formatText)
+			if got := keyringToken(tt.keyring, cfg.Logger, backendEcosystems); got != tt.want {
+				t.Errorf("keyringToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// copyToClipboard copies the output of the command to the system clipboard with writeAll
+// (clipboard.WriteAll outside of tests).
+//
+// The output was already printed to stdout, so failures, e.g. without a display server on a headless host,
+// are only reported as a warning to w.
+func copyToClipboard(w io.Writer, writeAll func(string) error, output string) {
+	if output == "" {
+		return
+	}
+	if err := writeAll(output); err != nil {
+		fmt.Fprintf(w, "Warning: Failed to copy the output to the clipboard: %v\n", err)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// TestCopyToClipboard tests the copyToClipboard function.
+func TestCopyToClipboard(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		output        string
+		writeErr      error
+		wantClipboard string
+		wantWarning   string
+	}{
+		{
+			name:          "copied",
+			output:        "Name:            lodash\n",
+			wantClipboard: "Name:            lodash\n",
+		},
+		{
+			name:        "clipboard unavailable",
+			output:      "Name:            lodash\n",
+			writeErr:    errors.New("no clipboard utilities available"),
+			wantWarning: "Warning: Failed to copy the output to the clipboard: no clipboard utilities available\n",
+		},
+		{
+			name:     "no output",
+			writeErr: errors.New("should not be called"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var clipboard string
+			writeAll := func(text string) error {
+				if tt.writeErr != nil {
+					return tt.writeErr
+				}
+				clipboard = text
+				return nil
+			}
+
+			var stderr bytes.Buffer
+			copyToClipboard(&stderr, writeAll, tt.output)
+
+			if clipboard != tt.wantClipboard {
+				t.Errorf("clipboard = %q, want %q", clipboard, tt.wantClipboard)
+			}
+			if stderr.String() != tt.wantWarning {
+				t.Errorf("stderr = %q, want %q", stderr.String(), tt.wantWarning)
+			}
+		})
+	}
+}
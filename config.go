@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// configCommand is the name of the config subcommand.
+	configCommand = "config"
+	// configShowCommand is the name of the config command showing the effective configuration.
+	configShowCommand = "show"
+	// configSourceFlag is the source of a setting set with a command line flag.
+	configSourceFlag = "flag"
+	// configSourceEnvironment is the source of a setting read from an environment variable.
+	configSourceEnvironment = "environment"
+	// configSourceKeyring is the source of a setting read from the OS keychain.
+	configSourceKeyring = "keyring"
+	// configSourceDefault is the source of a setting left to its built-in default.
+	configSourceDefault = "default"
+)
+
+// configValue is the effective value of a setting and where it came from.
+type configValue struct {
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// runConfig runs the config subcommand with its arguments.
+//
+// globalFlags are the parsed global options, whose effective values are shown by config show.
+func runConfig(cfg RunConfig, globalFlags *flag.FlagSet, args []string) int {
+	flags := flag.NewFlagSet(configCommand, flag.ContinueOnError)
+	flags.SetOutput(cfg.Stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(cfg.Stderr, "Usage: %s [OPTIONS] config show\n\n", os.Args[0])
+		fmt.Fprintf(cfg.Stderr, "Show the effective configuration, with the source of each setting: ")
+		fmt.Fprintf(cfg.Stderr, "%s, %s, %s or %s.\n",
+			configSourceFlag, configSourceEnvironment, configSourceKeyring, configSourceDefault)
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return exitInvalidArgs
+	}
+	if flags.NArg() != 1 || flags.Arg(0) != configShowCommand {
+		fmt.Fprintf(cfg.Stderr, "Error: Expected the config command %s, got %v\n\n", configShowCommand, flags.Args())
+		flags.Usage()
+		return exitInvalidArgs
+	}
+
+	// Resolve the token fallback as setupService does
+	tokenFallback := configValue{Value: os.Getenv(tokenEnvVar), Source: configSourceEnvironment}
+	if backend := globalFlags.Lookup("backend"); tokenFallback.Value == "" && backend != nil {
+		tokenFallback = configValue{
+			Value:  keyringToken(cfg.Keyring, cfg.Logger, backend.Value.String()),
+			Source: configSourceKeyring,
+		}
+	}
+
+	settings := effectiveConfig(globalFlags, tokenFallback)
+	if printErr := printConfig(cfg.Stdout, settings, cfg.Format); printErr != nil {
+		fmt.Fprintf(cfg.Stderr, "Error: %v\n", printErr)
+		return exitRuntimeError
+	}
+
+	return exitSuccess
+}
+
+// effectiveConfig returns the effective value and source of every flag of flags, keyed by flag name.
+//
+// If the token is not set with a flag, tokenFallback is used if not empty: $PURLINFO_TOKEN, or the key stored
+// in the keyring. The token is masked.
+func effectiveConfig(flags *flag.FlagSet, tokenFallback configValue) map[string]configValue {
+	set := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	settings := make(map[string]configValue)
+	flags.VisitAll(func(f *flag.Flag) {
+		setting := configValue{Value: f.Value.String(), Source: configSourceDefault}
+		if set[f.Name] {
+			setting.Source = configSourceFlag
+		}
+
+		if f.Name == "token" {
+			if !set[f.Name] && tokenFallback.Value != "" {
+				setting = tokenFallback
+			}
+			setting.Value = maskToken(setting.Value)
+		}
+
+		settings[f.Name] = setting
+	})
+
+	return settings
+}
+
+// printConfig prints the settings to w, sorted by name, in the given output format.
+//
+// The text output is YAML, with the source of each setting as a comment.
+func printConfig(w io.Writer, settings map[string]configValue, format string) error {
+	if format == formatJSON {
+		// encoding/json sorts the map keys
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(settings); encodeErr != nil {
+			return fmt.Errorf("failed to encode JSON: %w", encodeErr)
+		}
+		return nil
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(settings)) {
+		setting := settings[name]
+		fmt.Fprintf(w, "%s: %s # source: %s\n", name, yamlScalar(setting.Value), setting.Source)
+	}
+	return nil
+}
+
+// yamlScalar returns the value as a YAML scalar, double-quoted if it would not be read back as the same string.
+func yamlScalar(value string) string {
+	if value == "" || strings.TrimSpace(value) != value || strings.ContainsAny(value, "#\"'{}[],&*!|>%@`") ||
+		strings.Contains(value, ": ") {
+		return strconv.Quote(value)
+	}
+	return value
+}
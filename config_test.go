@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestGlobalFlags returns a flag set with a subset of the global options, parsed from args.
+func newTestGlobalFlags(t *testing.T, args ...string) *flag.FlagSet {
+	t.Helper()
+
+	flags := flag.NewFlagSet("purlinfo", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.String("backend", backendEcosystems, "")
+	flags.Duration("timeout", defaultTimeoutSec*time.Second, "")
+	flags.String("token", "", "")
+	flags.String("email", "", "")
+	if err := flags.Parse(args); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	return flags
+}
+
+// TestEffectiveConfig tests the effectiveConfig function.
+func TestEffectiveConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		args     []string
+		fallback configValue
+		want     map[string]configValue
+	}{
+		{
+			name: "defaults",
+			want: map[string]configValue{
+				"backend": {Value: backendEcosystems, Source: configSourceDefault},
+				"timeout": {Value: "30s", Source: configSourceDefault},
+				"token":   {Value: "", Source: configSourceDefault},
+				"email":   {Value: "", Source: configSourceDefault},
+			},
+		},
+		{
+			name: "flags",
+			args: []string{"-backend", backendRubyGems, "-timeout", "5s", "-token", "secret"},
+			want: map[string]configValue{
+				"backend": {Value: backendRubyGems, Source: configSourceFlag},
+				"timeout": {Value: "5s", Source: configSourceFlag},
+				"token":   {Value: maskedToken, Source: configSourceFlag},
+				"email":   {Value: "", Source: configSourceDefault},
+			},
+		},
+		{
+			name:     "token from environment",
+			fallback: configValue{Value: "secret", Source: configSourceEnvironment},
+			want: map[string]configValue{
+				"backend": {Value: backendEcosystems, Source: configSourceDefault},
+				"timeout": {Value: "30s", Source: configSourceDefault},
+				"token":   {Value: maskedToken, Source: configSourceEnvironment},
+				"email":   {Value: "", Source: configSourceDefault},
+			},
+		},
+		{
+			name:     "token from keyring",
+			fallback: configValue{Value: "secret", Source: configSourceKeyring},
+			want: map[string]configValue{
+				"backend": {Value: backendEcosystems, Source: configSourceDefault},
+				"timeout": {Value: "30s", Source: configSourceDefault},
+				"token":   {Value: maskedToken, Source: configSourceKeyring},
+				"email":   {Value: "", Source: configSourceDefault},
+			},
+		},
+		{
+			name:     "no token in keyring",
+			fallback: configValue{Source: configSourceKeyring},
+			want: map[string]configValue{
+				"backend": {Value: backendEcosystems, Source: configSourceDefault},
+				"timeout": {Value: "30s", Source: configSourceDefault},
+				"token":   {Value: "", Source: configSourceDefault},
+				"email":   {Value: "", Source: configSourceDefault},
+			},
+		},
+		{
+			name:     "token flag takes precedence over environment",
+			args:     []string{"-token", "from-flag"},
+			fallback: configValue{Value: "from-env", Source: configSourceEnvironment},
+			want: map[string]configValue{
+				"backend": {Value: backendEcosystems, Source: configSourceDefault},
+				"timeout": {Value: "30s", Source: configSourceDefault},
+				"token":   {Value: maskedToken, Source: configSourceFlag},
+				"email":   {Value: "", Source: configSourceDefault},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := effectiveConfig(newTestGlobalFlags(t, tt.args...), tt.fallback)
+			if len(got) != len(tt.want) {
+				t.Errorf("effectiveConfig() returned %d settings, want %d", len(got), len(tt.want))
+			}
+			for name, want := range tt.want {
+				if got[name] != want {
+					t.Errorf("effectiveConfig()[%q] = %+v, want %+v", name, got[name], want)
+				}
+			}
+		})
+	}
+}
+
+// TestRunConfig tests the runConfig function.
+func TestRunConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		args       []string
+		format     string
+		want       int
+		wantStdout string
+	}{
+		{
+			name:   "show as YAML",
+			args:   []string{"show"},
+			format: formatText,
+			want:   exitSuccess,
+			wantStdout: "backend: rubygems # source: flag\n" +
+				"email: \"\" # source: default\n" +
+				"timeout: 30s # source: default\n" +
+				"token: \"****\" # source: flag\n",
+		},
+		{
+			name:   "show as JSON",
+			args:   []string{"show"},
+			format: formatJSON,
+			want:   exitSuccess,
+		},
+		{
+			name: "missing command",
+			want: exitInvalidArgs,
+		},
+		{
+			name: "unknown command",
+			args: []string{"init"},
+			want: exitInvalidArgs,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, stdout, stderr := newTestRunConfig(false, tt.format)
+			globalFlags := newTestGlobalFlags(t, "-backend", backendRubyGems, "-token", "secret")
+
+			exitCode := runConfig(cfg, globalFlags, tt.args)
+
+			if exitCode != tt.want {
+				t.Errorf("runConfig() = %d, want %d\nStderr: %s", exitCode, tt.want, stderr.String())
+			}
+			if tt.want != exitSuccess {
+				if !strings.Contains(stderr.String(), "Error:") {
+					t.Errorf("runConfig() stderr = %q, want an error message", stderr.String())
+				}
+				return
+			}
+			if tt.wantStdout != "" && stdout.String() != tt.wantStdout {
+				t.Errorf("runConfig() output = %q, want %q", stdout.String(), tt.wantStdout)
+			}
+			if tt.format == formatJSON {
+				var got map[string]configValue
+				if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+					t.Fatalf("runConfig() produced invalid JSON: %v\nOutput: %s", err, stdout.String())
+				}
+				if want := (configValue{Value: backendRubyGems, Source: configSourceFlag}); got["backend"] != want {
+					t.Errorf("runConfig() JSON backend = %+v, want %+v", got["backend"], want)
+				}
+			}
+		})
+	}
+}
+
+// TestYAMLScalar tests the yamlScalar function.
+func TestYAMLScalar(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "30s", want: "30s"},
+		{value: "https://registry.example.com", want: "https://registry.example.com"},
+		{value: "", want: `""`},
+		{value: "****", want: `"****"`},
+		{value: "me@example.com", want: `"me@example.com"`},
+		{value: "a: b", want: `"a: b"`},
+		{value: " padded", want: `" padded"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			if got := yamlScalar(tt.value); got != tt.want {
+				t.Errorf("yamlScalar(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPrintConfig_Sorted tests that the settings are printed sorted by name.
+func TestPrintConfig_Sorted(t *testing.T) {
+	t.Parallel()
+
+	settings := map[string]configValue{
+		"timeout": {Value: "30s", Source: configSourceDefault},
+		"backend": {Value: backendEcosystems, Source: configSourceDefault},
+		"format":  {Value: formatText, Source: configSourceDefault},
+	}
+
+	var buf bytes.Buffer
+	if err := printConfig(&buf, settings, formatText); err != nil {
+		t.Fatalf("printConfig() unexpected error = %v", err)
+	}
+
+	var names []string
+	for line := range strings.Lines(buf.String()) {
+		name, _, _ := strings.Cut(line, ":")
+		names = append(names, name)
+	}
+	if got := strings.Join(names, ","); got != "backend,format,timeout" {
+		t.Errorf("printConfig() names = %s, want backend,format,timeout", got)
+	}
+}
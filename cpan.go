@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+const (
+	// cpanBaseURL is the base URL for the MetaCPAN API.
+	//
+	// See https://github.com/metacpan/metacpan-api/blob/master/docs/API-docs.md
+	cpanBaseURL = "https://fastapi.metacpan.org"
+	// cpanModuleSeparator separates the parts of a Perl module name (e.g., URI::PackageURL).
+	cpanModuleSeparator = "::"
+)
+
+// CPANService is the service for the MetaCPAN API of Perl modules and distributions.
+//
+// Following the purl spec, a purl with a PAUSE author ID (all uppercase) as namespace names a distribution
+// (pkg:cpan/OALDERS/libwww-perl), and a purl without namespace names a module (pkg:cpan/Moose::Util).
+// Module purls written with slashes are also accepted: a namespace that is not a PAUSE ID holds the leading
+// parts of the module name (pkg:cpan/Moose/Util), and so does an uppercase namespace when there is no such
+// distribution (pkg:cpan/LWP/UserAgent). The metadata is that of the distribution release.
+type CPANService struct {
+	baseURL string
+	client  *http.Client
+}
+
+var _ Service = (*CPANService)(nil)
+
+// CPANServiceOptions are the options for the CPANService.
+type CPANServiceOptions struct {
+	// BaseURL is the base URL for the MetaCPAN API.
+	// If empty, defaults to fastapi.metacpan.org.
+	BaseURL string
+	// Client is the HTTP client to use for the MetaCPAN API.
+	// If nil, defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// NewCPANService creates a new CPANService.
+func NewCPANService(opts CPANServiceOptions) *CPANService {
+	// Default to the MetaCPAN API base URL.
+	baseURL := cpanBaseURL
+	if opts.BaseURL != "" {
+		baseURL = opts.BaseURL
+	}
+	// Default to the default HTTP client.
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &CPANService{
+		baseURL: baseURL,
+		client:  client,
+	}
+}
+
+// cpanModuleResponse is the response from the MetaCPAN module endpoint.
+type cpanModuleResponse struct {
+	Version      string `json:"version"`
+	Distribution string `json:"distribution"`
+	Author       string `json:"author"`
+	Release      string `json:"release"`
+}
+
+// cpanReleaseResponse is the response from the MetaCPAN release endpoint.
+type cpanReleaseResponse struct {
+	Distribution string   `json:"distribution"`
+	Version      string   `json:"version"`
+	Abstract     *string  `json:"abstract"`
+	License      []string `json:"license"`
+	Resources    struct {
+		Homepage   *string `json:"homepage"`
+		Repository *struct {
+			URL string `json:"url"`
+			Web string `json:"web"`
+		} `json:"repository"`
+		Bugtracker *struct {
+			Web string `json:"web"`
+		} `json:"bugtracker"`
+	} `json:"resources"`
+}
+
+// GetPackageInfo returns the information about a Perl module or distribution.
+//
+// Licenses are the CPAN::Meta license identifiers (e.g., perl_5), not SPDX identifiers, so they are
+// flagged with NonSPDXLicenses.
+func (s *CPANService) GetPackageInfo(ctx context.Context, purl packageurl.PackageURL) (PackageInfo, error) {
+	if purl.Type != packageurl.TypeCpan {
+		return PackageInfo{}, fmt.Errorf("%w: %s", ErrUnsupportedEcosystem, purl.Type)
+	}
+
+	var (
+		name    string
+		version string
+		release cpanReleaseResponse
+		err     error
+	)
+	if isPAUSEID(purl.Namespace) {
+		name = purl.Name
+		release, err = s.getDistributionRelease(ctx, purl.Namespace, purl.Name, purl.Version)
+		version = release.Version
+		if errors.Is(err, ErrPackageNotFound) {
+			// Uppercase module namespaces, such as LWP or HTTP, look like PAUSE IDs
+			name = cpanModuleName(purl)
+			version, release, err = s.getModuleRelease(ctx, name, purl.Version)
+		}
+	} else {
+		name = cpanModuleName(purl)
+		version, release, err = s.getModuleRelease(ctx, name, purl.Version)
+	}
+	if err != nil {
+		return PackageInfo{}, err
+	}
+
+	packageInfo := PackageInfo{
+		Name:            name,
+		Version:         version,
+		Licenses:        release.License,
+		NonSPDXLicenses: true,
+		Homepage:        stringValue(release.Resources.Homepage),
+		Description:     stringValue(release.Abstract),
+		Ecosystem:       purl.Type,
+	}
+	if repo := release.Resources.Repository; repo != nil {
+		packageInfo.RepositoryURL = repo.Web
+		if packageInfo.RepositoryURL == "" {
+			packageInfo.RepositoryURL = repo.URL
+		}
+	}
+	if release.Resources.Bugtracker != nil {
+		packageInfo.BugTrackerURL = release.Resources.Bugtracker.Web
+	}
+	if packageInfo.Licenses == nil {
+		packageInfo.Licenses = []string{}
+	}
+
+	return packageInfo, nil
+}
+
+// getModuleRelease returns the version of a module and the release of its distribution.
+//
+// The module is looked up at its latest version; an older version must also be the version of the
+// distribution release, which is the case for most modules.
+func (s *CPANService) getModuleRelease(
+	ctx context.Context, module, version string,
+) (string, cpanReleaseResponse, error) {
+	var result cpanModuleResponse
+	if err := getJSON(ctx, s.client, s.baseURL+"/v1/module/"+url.PathEscape(module), nil, &result); err != nil {
+		return "", cpanReleaseResponse{}, err
+	}
+
+	if version == "" || version == result.Version {
+		releaseURL := fmt.Sprintf("%s/v1/release/%s/%s",
+			s.baseURL, url.PathEscape(result.Author), url.PathEscape(result.Release))
+		var release cpanReleaseResponse
+		if err := getJSON(ctx, s.client, releaseURL, nil, &release); err != nil {
+			return "", cpanReleaseResponse{}, err
+		}
+		return result.Version, release, nil
+	}
+
+	release, err := s.getDistributionRelease(ctx, result.Author, result.Distribution, version)
+	if err != nil {
+		return "", cpanReleaseResponse{}, err
+	}
+	return version, release, nil
+}
+
+// getDistributionRelease returns the release of a distribution by the author at the version,
+// or the latest release of the distribution if the version is empty.
+func (s *CPANService) getDistributionRelease(
+	ctx context.Context, author, distribution, version string,
+) (cpanReleaseResponse, error) {
+	releaseURL := s.baseURL + "/v1/release/" + url.PathEscape(distribution)
+	if version != "" {
+		releaseURL = fmt.Sprintf("%s/v1/release/%s/%s",
+			s.baseURL, url.PathEscape(author), url.PathEscape(distribution+"-"+version))
+	}
+
+	var release cpanReleaseResponse
+	if err := getJSON(ctx, s.client, releaseURL, nil, &release); err != nil {
+		if errors.Is(err, ErrPackageNotFound) && version != "" {
+			return cpanReleaseResponse{}, fmt.Errorf("%w: %s version %s", ErrPackageNotFound, distribution, version)
+		}
+		return cpanReleaseResponse{}, err
+	}
+	return release, nil
+}
+
+// cpanModuleName returns the Perl module name of a purl naming a module, joining its namespace
+// and name with :: (e.g., pkg:cpan/Moose/Util is Moose::Util).
+func cpanModuleName(purl packageurl.PackageURL) string {
+	if purl.Namespace == "" {
+		return purl.Name
+	}
+	return strings.ReplaceAll(purl.Namespace, "/", cpanModuleSeparator) + cpanModuleSeparator + purl.Name
+}
+
+// isPAUSEID reports whether the purl namespace is a PAUSE author ID, which is made of uppercase letters,
+// digits and dashes (e.g., OALDERS).
+func isPAUSEID(namespace string) bool {
+	if namespace == "" {
+		return false
+	}
+	for _, r := range namespace {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
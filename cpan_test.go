@@ -0,0 +1,310 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// TestNewCPANService tests the NewCPANService function.
+func TestNewCPANService(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default options", func(t *testing.T) {
+		t.Parallel()
+
+		service := NewCPANService(CPANServiceOptions{})
+
+		if service.baseURL != cpanBaseURL {
+			t.Errorf("baseURL = %q, want %q", service.baseURL, cpanBaseURL)
+		}
+		if service.client != http.DefaultClient {
+			t.Error("client should be http.DefaultClient when not provided")
+		}
+	})
+
+	t.Run("custom base URL", func(t *testing.T) {
+		t.Parallel()
+
+		customURL := "https://metacpan.example.com"
+		service := NewCPANService(CPANServiceOptions{
+			BaseURL: customURL,
+		})
+
+		if service.baseURL != customURL {
+			t.Errorf("baseURL = %q, want %q", service.baseURL, customURL)
+		}
+	})
+}
+
+// TestCPANService_GetPackageInfo tests the GetPackageInfo method.
+func TestCPANService_GetPackageInfo(t *testing.T) {
+	t.Parallel()
+
+	const mooseRelease = `{
+		"distribution": "Moose",
+		"version": "2.2207",
+		"abstract": "A postmodern object system for Perl 5",
+		"license": ["perl_5"],
+		"resources": {
+			"homepage": "http://moose.perl.org/",
+			"repository": {"url": "git://github.com/moose/Moose.git", "web": "https://github.com/moose/Moose"},
+			"bugtracker": {"web": "https://rt.cpan.org/Dist/Display.html?Name=Moose"}
+		}
+	}`
+	const mooseUtilModule = `{
+		"version": "2.2207",
+		"distribution": "Moose",
+		"author": "ETHER",
+		"release": "Moose-2.2207"
+	}`
+	mooseInfo := func(name, version string) PackageInfo {
+		return PackageInfo{
+			Name:          name,
+			Version:       version,
+			Licenses:      []string{"perl_5"},
+			Homepage:      "http://moose.perl.org/",
+			RepositoryURL: "https://github.com/moose/Moose",
+			Description:   "A postmodern object system for Perl 5",
+			Ecosystem:     "cpan",
+			BugTrackerURL: "https://rt.cpan.org/Dist/Display.html?Name=Moose",
+		}
+	}
+
+	// mockResponse is the status code and body of the response to a path.
+	type mockResponse struct {
+		statusCode int
+		body       string
+	}
+	const notFound = `{"code": 404, "message": "Not found"}`
+
+	// uppercaseModule returns the responses to the lookups of a module purl with an uppercase namespace
+	// (pkg:cpan/<namespace>/<name>): no distribution <name>, then the module <namespace>::<name>.
+	uppercaseModule := func(namespace, name, author, distribution, version string) map[string]mockResponse {
+		release := distribution + "-" + version
+		return map[string]mockResponse{
+			"/v1/release/" + name: {http.StatusNotFound, notFound},
+			"/v1/module/" + namespace + "::" + name: {http.StatusOK, `{"version": "` + version +
+				`", "distribution": "` + distribution + `", "author": "` + author + `", "release": "` + release + `"}`},
+			"/v1/release/" + author + "/" + release: {http.StatusOK, `{"distribution": "` + distribution +
+				`", "version": "` + version + `", "license": ["perl_5"]}`},
+		}
+	}
+	uppercaseModuleInfo := func(name, version string) PackageInfo {
+		return PackageInfo{Name: name, Version: version, Licenses: []string{"perl_5"}, Ecosystem: "cpan"}
+	}
+
+	tests := []struct {
+		name      string
+		responses map[string]mockResponse
+		purl      string
+		want      PackageInfo
+		wantErr   error
+	}{
+		{
+			name: "module",
+			responses: map[string]mockResponse{
+				"/v1/module/Moose::Util":         {http.StatusOK, mooseUtilModule},
+				"/v1/release/ETHER/Moose-2.2207": {http.StatusOK, mooseRelease},
+			},
+			purl: "pkg:cpan/Moose::Util",
+			want: mooseInfo("Moose::Util", "2.2207"),
+		},
+		{
+			name: "module with namespace",
+			responses: map[string]mockResponse{
+				"/v1/module/Moose::Util":         {http.StatusOK, mooseUtilModule},
+				"/v1/release/ETHER/Moose-2.2207": {http.StatusOK, mooseRelease},
+			},
+			purl: "pkg:cpan/Moose/Util@2.2207",
+			want: mooseInfo("Moose::Util", "2.2207"),
+		},
+		{
+			name: "older module version",
+			responses: map[string]mockResponse{
+				"/v1/module/Moose::Util":         {http.StatusOK, mooseUtilModule},
+				"/v1/release/ETHER/Moose-2.2206": {http.StatusOK, strings.Replace(mooseRelease, "2.2207", "2.2206", 1)},
+			},
+			purl: "pkg:cpan/Moose::Util@2.2206",
+			want: mooseInfo("Moose::Util", "2.2206"),
+		},
+		{
+			name: "distribution",
+			responses: map[string]mockResponse{
+				"/v1/release/Moose": {http.StatusOK, mooseRelease},
+			},
+			purl: "pkg:cpan/ETHER/Moose",
+			want: mooseInfo("Moose", "2.2207"),
+		},
+		{
+			name: "distribution version without resources",
+			responses: map[string]mockResponse{
+				"/v1/release/OALDERS/libwww-perl-6.76": {http.StatusOK, `{"distribution": "libwww-perl", "version": "6.76"}`},
+			},
+			purl: "pkg:cpan/OALDERS/libwww-perl@6.76",
+			want: PackageInfo{
+				Name:      "libwww-perl",
+				Version:   "6.76",
+				Licenses:  []string{},
+				Ecosystem: "cpan",
+			},
+		},
+		{
+			name:      "uppercase module namespace LWP",
+			responses: uppercaseModule("LWP", "UserAgent", "OALDERS", "libwww-perl", "6.76"),
+			purl:      "pkg:cpan/LWP/UserAgent",
+			want:      uppercaseModuleInfo("LWP::UserAgent", "6.76"),
+		},
+		{
+			name:      "uppercase module namespace HTTP",
+			responses: uppercaseModule("HTTP", "Tiny", "HAARG", "HTTP-Tiny", "0.088"),
+			purl:      "pkg:cpan/HTTP/Tiny",
+			want:      uppercaseModuleInfo("HTTP::Tiny", "0.088"),
+		},
+		{
+			name:      "uppercase module namespace JSON",
+			responses: uppercaseModule("JSON", "PP", "ISHIGAKI", "JSON-PP", "4.16"),
+			purl:      "pkg:cpan/JSON/PP",
+			want:      uppercaseModuleInfo("JSON::PP", "4.16"),
+		},
+		{
+			name:      "uppercase module namespace DBD",
+			responses: uppercaseModule("DBD", "SQLite", "ISHIGAKI", "DBD-SQLite", "1.74"),
+			purl:      "pkg:cpan/DBD/SQLite",
+			want:      uppercaseModuleInfo("DBD::SQLite", "1.74"),
+		},
+		{
+			name:      "uppercase module namespace IO",
+			responses: uppercaseModule("IO", "Socket", "TODDR", "IO", "1.55"),
+			purl:      "pkg:cpan/IO/Socket",
+			want:      uppercaseModuleInfo("IO::Socket", "1.55"),
+		},
+		{
+			name: "missing distribution version",
+			responses: map[string]mockResponse{
+				"/v1/release/ETHER/Moose-0.01": {http.StatusNotFound, notFound},
+				"/v1/module/ETHER::Moose":      {http.StatusNotFound, notFound},
+			},
+			purl:    "pkg:cpan/ETHER/Moose@0.01",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name: "missing module",
+			responses: map[string]mockResponse{
+				"/v1/module/No::Such": {http.StatusNotFound, `{"code": 404, "message": "Not found"}`},
+			},
+			purl:    "pkg:cpan/No::Such",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name: "malformed JSON",
+			responses: map[string]mockResponse{
+				"/v1/module/Moose::Util": {http.StatusOK, `{invalid json}`},
+			},
+			purl:    "pkg:cpan/Moose::Util",
+			wantErr: ErrInvalidResponse,
+		},
+		{
+			name:    "unsupported ecosystem",
+			purl:    "pkg:pub/http",
+			wantErr: ErrUnsupportedEcosystem,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				response, ok := tt.responses[r.URL.Path]
+				if !ok {
+					t.Errorf("unexpected request path %q", r.URL.Path)
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(response.statusCode)
+				_, _ = w.Write([]byte(response.body))
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewCPANService(CPANServiceOptions{
+				BaseURL: server.URL,
+			})
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.GetPackageInfo(context.Background(), purl)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetPackageInfo() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+
+			if got.Name != tt.want.Name {
+				t.Errorf("GetPackageInfo() Name = %q, want %q", got.Name, tt.want.Name)
+			}
+			if got.Version != tt.want.Version {
+				t.Errorf("GetPackageInfo() Version = %q, want %q", got.Version, tt.want.Version)
+			}
+			if got.Ecosystem != tt.want.Ecosystem {
+				t.Errorf("GetPackageInfo() Ecosystem = %q, want %q", got.Ecosystem, tt.want.Ecosystem)
+			}
+			if !equalStringSlices(got.Licenses, tt.want.Licenses) {
+				t.Errorf("GetPackageInfo() Licenses = %v, want %v", got.Licenses, tt.want.Licenses)
+			}
+			if !got.NonSPDXLicenses {
+				t.Error("GetPackageInfo() NonSPDXLicenses = false, want true")
+			}
+			if got.Homepage != tt.want.Homepage {
+				t.Errorf("GetPackageInfo() Homepage = %q, want %q", got.Homepage, tt.want.Homepage)
+			}
+			if got.RepositoryURL != tt.want.RepositoryURL {
+				t.Errorf("GetPackageInfo() RepositoryURL = %q, want %q", got.RepositoryURL, tt.want.RepositoryURL)
+			}
+			if got.Description != tt.want.Description {
+				t.Errorf("GetPackageInfo() Description = %q, want %q", got.Description, tt.want.Description)
+			}
+			if got.BugTrackerURL != tt.want.BugTrackerURL {
+				t.Errorf("GetPackageInfo() BugTrackerURL = %q, want %q", got.BugTrackerURL, tt.want.BugTrackerURL)
+			}
+		})
+	}
+}
+
+// TestIsPAUSEID tests the isPAUSEID function.
+func TestIsPAUSEID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		namespace string
+		want      bool
+	}{
+		{namespace: "OALDERS", want: true},
+		{namespace: "LEONT-2", want: true},
+		{namespace: "Moose", want: false},
+		{namespace: "Moose/Meta", want: false},
+		{namespace: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.namespace, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isPAUSEID(tt.namespace); got != tt.want {
+				t.Errorf("isPAUSEID(%q) = %v, want %v", tt.namespace, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// cranBaseURL is the base URL for the CRAN package database (crandb) API.
+//
+// See https://github.com/r-hub/crandb
+const cranBaseURL = "https://crandb.r-pkg.org"
+
+// CRANService is the service for the crandb API of R packages on CRAN.
+//
+// CRAN licenses are R license specifications (e.g., "GPL (>= 2) | BSD_2_clause"), not SPDX expressions:
+// they are returned verbatim, as a single license, and flagged with NonSPDXLicenses.
+type CRANService struct {
+	baseURL string
+	client  *http.Client
+}
+
+var _ Service = (*CRANService)(nil)
+
+// CRANServiceOptions are the options for the CRANService.
+type CRANServiceOptions struct {
+	// BaseURL is the base URL for the crandb API.
+	// If empty, defaults to crandb.r-pkg.org.
+	BaseURL string
+	// Client is the HTTP client to use for the crandb API.
+	// If nil, defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// NewCRANService creates a new CRANService.
+func NewCRANService(opts CRANServiceOptions) *CRANService {
+	// Default to the crandb API base URL.
+	baseURL := cranBaseURL
+	if opts.BaseURL != "" {
+		baseURL = opts.BaseURL
+	}
+	// Default to the default HTTP client.
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &CRANService{
+		baseURL: baseURL,
+		client:  client,
+	}
+}
+
+// cranPackageResponse is the response from the crandb package endpoint: the DESCRIPTION file
+// of a version of the package.
+type cranPackageResponse struct {
+	Package     string `json:"Package"`
+	Version     string `json:"Version"`
+	Description string `json:"Description"`
+	License     string `json:"License"`
+	URL         string `json:"URL"`
+	BugReports  string `json:"BugReports"`
+}
+
+// GetPackageInfo returns the information about a package.
+//
+// CRAN purls require a version; without one, the latest version is looked up.
+// The URL field of a DESCRIPTION file lists several URLs: the first one is the homepage, and the first
+// GitHub or GitLab one is the repository.
+func (s *CRANService) GetPackageInfo(ctx context.Context, purl packageurl.PackageURL) (PackageInfo, error) {
+	if purl.Type != packageurl.TypeCran {
+		return PackageInfo{}, fmt.Errorf("%w: %s", ErrUnsupportedEcosystem, purl.Type)
+	}
+
+	packageURL := s.baseURL + "/" + url.PathEscape(purl.Name)
+	if purl.Version != "" {
+		packageURL += "/" + url.PathEscape(purl.Version)
+	}
+
+	var pkg cranPackageResponse
+	if err := getJSON(ctx, s.client, packageURL, nil, &pkg); err != nil {
+		return PackageInfo{}, err
+	}
+
+	licenses := []string{}
+	if pkg.License != "" {
+		licenses = []string{pkg.License}
+	}
+
+	packageInfo := PackageInfo{
+		Name:            pkg.Package,
+		Version:         pkg.Version,
+		Licenses:        licenses,
+		NonSPDXLicenses: true,
+		Description:     strings.Join(strings.Fields(pkg.Description), " "),
+		Ecosystem:       purl.Type,
+		BugTrackerURL:   strings.TrimSpace(pkg.BugReports),
+	}
+	for _, link := range cranURLs(pkg.URL) {
+		if packageInfo.Homepage == "" {
+			packageInfo.Homepage = link
+		}
+		if _, ok := forgeRepository(link); ok && packageInfo.RepositoryURL == "" {
+			packageInfo.RepositoryURL = link
+		}
+	}
+
+	return packageInfo, nil
+}
+
+// cranURLs returns the URLs of the URL field of a DESCRIPTION file, which are separated by commas
+// or whitespace.
+func cranURLs(field string) []string {
+	return strings.FieldsFunc(field, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
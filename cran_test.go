@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// TestNewCRANService tests the NewCRANService function.
+func TestNewCRANService(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default options", func(t *testing.T) {
+		t.Parallel()
+
+		service := NewCRANService(CRANServiceOptions{})
+
+		if service.baseURL != cranBaseURL {
+			t.Errorf("baseURL = %q, want %q", service.baseURL, cranBaseURL)
+		}
+		if service.client != http.DefaultClient {
+			t.Error("client should be http.DefaultClient when not provided")
+		}
+	})
+
+	t.Run("custom base URL", func(t *testing.T) {
+		t.Parallel()
+
+		customURL := "https://crandb.example.com"
+		service := NewCRANService(CRANServiceOptions{
+			BaseURL: customURL,
+		})
+
+		if service.baseURL != customURL {
+			t.Errorf("baseURL = %q, want %q", service.baseURL, customURL)
+		}
+	})
+}
+
+// TestCRANService_GetPackageInfo tests the GetPackageInfo method.
+func TestCRANService_GetPackageInfo(t *testing.T) {
+	t.Parallel()
+
+	const ggplot2Package = `{
+		"Package": "ggplot2",
+		"Version": "3.5.1",
+		"Title": "Create Elegant Data Visualisations Using the Grammar of Graphics",
+		"Description": "A system for 'declaratively' creating graphics,\n    based on \"The Grammar of Graphics\".",
+		"License": "MIT + file LICENSE",
+		"URL": "https://ggplot2.tidyverse.org,\nhttps://github.com/tidyverse/ggplot2",
+		"BugReports": "https://github.com/tidyverse/ggplot2/issues"
+	}`
+
+	tests := []struct {
+		name           string
+		mockResponse   string
+		mockStatusCode int
+		purl           string
+		wantPath       string
+		want           PackageInfo
+		wantErr        error
+	}{
+		{
+			name:           "package",
+			mockResponse:   ggplot2Package,
+			mockStatusCode: http.StatusOK,
+			purl:           "pkg:cran/ggplot2@3.5.1",
+			wantPath:       "/ggplot2/3.5.1",
+			want: PackageInfo{
+				Name:          "ggplot2",
+				Version:       "3.5.1",
+				Licenses:      []string{"MIT + file LICENSE"},
+				Homepage:      "https://ggplot2.tidyverse.org",
+				RepositoryURL: "https://github.com/tidyverse/ggplot2",
+				Description:   `A system for 'declaratively' creating graphics, based on "The Grammar of Graphics".`,
+				Ecosystem:     "cran",
+				BugTrackerURL: "https://github.com/tidyverse/ggplot2/issues",
+			},
+		},
+		{
+			name: "version with compound license",
+			mockResponse: `{
+				"Package": "Matrix",
+				"Version": "1.6-5",
+				"License": "GPL (>= 2) | file LICENCE",
+				"URL": "https://Matrix.R-forge.R-project.org"
+			}`,
+			mockStatusCode: http.StatusOK,
+			purl:           "pkg:cran/Matrix@1.6-5",
+			wantPath:       "/Matrix/1.6-5",
+			want: PackageInfo{
+				Name:      "Matrix",
+				Version:   "1.6-5",
+				Licenses:  []string{"GPL (>= 2) | file LICENCE"},
+				Homepage:  "https://Matrix.R-forge.R-project.org",
+				Ecosystem: "cran",
+			},
+		},
+		{
+			name:           "package without license",
+			mockResponse:   `{"Package": "tiny", "Version": "0.1"}`,
+			mockStatusCode: http.StatusOK,
+			purl:           "pkg:cran/tiny@0.1",
+			wantPath:       "/tiny/0.1",
+			want: PackageInfo{
+				Name:      "tiny",
+				Version:   "0.1",
+				Licenses:  []string{},
+				Ecosystem: "cran",
+			},
+		},
+		{
+			name:           "HTTP 404 error",
+			mockResponse:   `{"error": "not_found", "reason": "missing"}`,
+			mockStatusCode: http.StatusNotFound,
+			purl:           "pkg:cran/ggplot2@0.0.1",
+			wantPath:       "/ggplot2/0.0.1",
+			wantErr:        ErrPackageNotFound,
+		},
+		{
+			name:           "malformed JSON",
+			mockResponse:   `{invalid json}`,
+			mockStatusCode: http.StatusOK,
+			purl:           "pkg:cran/ggplot2@3.5.1",
+			wantPath:       "/ggplot2/3.5.1",
+			wantErr:        ErrInvalidResponse,
+		},
+		{
+			name:    "unsupported ecosystem",
+			purl:    "pkg:cpan/Moose::Util",
+			wantErr: ErrUnsupportedEcosystem,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.wantPath {
+					t.Errorf("request path = %q, want %q", r.URL.Path, tt.wantPath)
+				}
+
+				w.WriteHeader(tt.mockStatusCode)
+				_, _ = w.Write([]byte(tt.mockResponse))
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewCRANService(CRANServiceOptions{
+				BaseURL: server.URL,
+			})
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.GetPackageInfo(context.Background(), purl)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetPackageInfo() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+
+			if got.Name != tt.want.Name {
+				t.Errorf("GetPackageInfo() Name = %q, want %q", got.Name, tt.want.Name)
+			}
+			if got.Version != tt.want.Version {
+				t.Errorf("GetPackageInfo() Version = %q, want %q", got.Version, tt.want.Version)
+			}
+			if got.Ecosystem != tt.want.Ecosystem {
+				t.Errorf("GetPackageInfo() Ecosystem = %q, want %q", got.Ecosystem, tt.want.Ecosystem)
+			}
+			if !equalStringSlices(got.Licenses, tt.want.Licenses) {
+				t.Errorf("GetPackageInfo() Licenses = %v, want %v", got.Licenses, tt.want.Licenses)
+			}
+			if !got.NonSPDXLicenses {
+				t.Error("GetPackageInfo() NonSPDXLicenses = false, want true")
+			}
+			if got.Homepage != tt.want.Homepage {
+				t.Errorf("GetPackageInfo() Homepage = %q, want %q", got.Homepage, tt.want.Homepage)
+			}
+			if got.RepositoryURL != tt.want.RepositoryURL {
+				t.Errorf("GetPackageInfo() RepositoryURL = %q, want %q", got.RepositoryURL, tt.want.RepositoryURL)
+			}
+			if got.Description != tt.want.Description {
+				t.Errorf("GetPackageInfo() Description = %q, want %q", got.Description, tt.want.Description)
+			}
+			if got.BugTrackerURL != tt.want.BugTrackerURL {
+				t.Errorf("GetPackageInfo() BugTrackerURL = %q, want %q", got.BugTrackerURL, tt.want.BugTrackerURL)
+			}
+		})
+	}
+}
+
+// TestCRANURLs tests the cranURLs function.
+func TestCRANURLs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		field string
+		want  []string
+	}{
+		{name: "empty", field: "", want: []string{}},
+		{name: "single URL", field: "https://r-lib.org", want: []string{"https://r-lib.org"}},
+		{
+			name:  "comma separated",
+			field: "https://ggplot2.tidyverse.org, https://github.com/tidyverse/ggplot2",
+			want:  []string{"https://ggplot2.tidyverse.org", "https://github.com/tidyverse/ggplot2"},
+		},
+		{
+			name:  "newline separated",
+			field: "https://a.example.com\n    https://b.example.com",
+			want:  []string{"https://a.example.com", "https://b.example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := cranURLs(tt.field); !equalStringSlices(got, tt.want) {
+				t.Errorf("cranURLs(%q) = %v, want %v", tt.field, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/package-url/packageurl-go"
+)
+
+const (
+	// dockerHubBaseURL is the base URL for the Docker Hub API.
+	//
+	// See https://docs.docker.com/reference/api/hub/latest/
+	dockerHubBaseURL = "https://hub.docker.com"
+	// dockerHubOfficialNamespace is the namespace of the official images, which docker purls may omit.
+	dockerHubOfficialNamespace = "library"
+	// dockerHubDefaultIndex is the host and path of the default registry of Docker (https://index.docker.io/v1/),
+	// which names no repository.
+	dockerHubDefaultIndex = "index.docker.io/v1"
+	// dockerHubTagsPageSize is the number of tags requested per page when resolving a digest.
+	dockerHubTagsPageSize = 100
+	// dockerHubMaxTagsPages is the maximum number of pages of tags searched for a digest.
+	dockerHubMaxTagsPages = 10
+)
+
+// DockerHubService is the service for container images on Docker Hub.
+//
+// Docker Hub does not serve the labels of the images, so licenses are not available.
+// Images of other registries (a repository_url qualifier) are not supported, and oci purls are only
+// supported with a Docker Hub repository_url.
+type DockerHubService struct {
+	baseURL string
+	client  *http.Client
+}
+
+var _ Service = (*DockerHubService)(nil)
+
+// DockerHubServiceOptions are the options for the DockerHubService.
+type DockerHubServiceOptions struct {
+	// BaseURL is the base URL for the Docker Hub API.
+	// If empty, defaults to hub.docker.com.
+	BaseURL string
+	// Client is the HTTP client to use for the Docker Hub API.
+	// If nil, defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// NewDockerHubService creates a new DockerHubService.
+func NewDockerHubService(opts DockerHubServiceOptions) *DockerHubService {
+	// Default to the Docker Hub API base URL.
+	baseURL := dockerHubBaseURL
+	if opts.BaseURL != "" {
+		baseURL = opts.BaseURL
+	}
+	// Default to the default HTTP client.
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &DockerHubService{
+		baseURL: baseURL,
+		client:  client,
+	}
+}
+
+// dockerHubRepositoryResponse is the response from the Docker Hub repositories endpoint.
+type dockerHubRepositoryResponse struct {
+	Namespace   string     `json:"namespace"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	PullCount   *int       `json:"pull_count"`
+	LastUpdated *time.Time `json:"last_updated"`
+}
+
+// dockerHubTagResponse is the response from the Docker Hub tags endpoint.
+type dockerHubTagResponse struct {
+	Name          string     `json:"name"`
+	Digest        string     `json:"digest"`
+	LastUpdated   *time.Time `json:"last_updated"`
+	TagLastPushed *time.Time `json:"tag_last_pushed"`
+	// Images are the platform images of a multi-platform tag, each with its own digest.
+	Images []struct {
+		Digest string `json:"digest"`
+	} `json:"images"`
+}
+
+// dockerHubTagsResponse is a page of the response from the Docker Hub tags list endpoint.
+type dockerHubTagsResponse struct {
+	Next    *string                `json:"next"`
+	Results []dockerHubTagResponse `json:"results"`
+}
+
+// GetPackageInfo returns the information about a container image on Docker Hub.
+//
+// The version of a docker purl must be a tag of the image, and the version of an oci purl a digest,
+// which is resolved through the tags list. Without a version, LastPublishedDate is the time the image
+// was last pushed.
+func (s *DockerHubService) GetPackageInfo(ctx context.Context, purl packageurl.PackageURL) (PackageInfo, error) {
+	namespace, name, err := dockerHubRepository(purl)
+	if err != nil {
+		return PackageInfo{}, err
+	}
+	repoURL := fmt.Sprintf("%s/v2/repositories/%s/%s", s.baseURL, url.PathEscape(namespace), url.PathEscape(name))
+
+	var repo dockerHubRepositoryResponse
+	if err := getJSON(ctx, s.client, repoURL, nil, &repo); err != nil {
+		return PackageInfo{}, err
+	}
+
+	lastPublished := repo.LastUpdated
+	if purl.Version != "" {
+		var tag dockerHubTagResponse
+		if purl.Type == packageurl.TypeOCI {
+			tag, err = s.findDigestTag(ctx, repoURL, purl.Version)
+		} else {
+			tag, err = s.getTag(ctx, repoURL, purl.Version)
+		}
+		if err != nil {
+			return PackageInfo{}, err
+		}
+		lastPublished = tag.TagLastPushed
+		if lastPublished == nil {
+			lastPublished = tag.LastUpdated
+		}
+	}
+
+	return PackageInfo{
+		Name:              namespace + "/" + name,
+		Version:           purl.Version,
+		Licenses:          []string{},
+		Homepage:          dockerHubPageURL(namespace, name),
+		Description:       repo.Description,
+		Ecosystem:         purl.Type,
+		DownloadCount:     repo.PullCount,
+		LastPublishedDate: lastPublished,
+	}, nil
+}
+
+// getTag returns the tag of the image repository at repoURL.
+func (s *DockerHubService) getTag(ctx context.Context, repoURL, name string) (dockerHubTagResponse, error) {
+	var tag dockerHubTagResponse
+	if err := getJSON(ctx, s.client, repoURL+"/tags/"+url.PathEscape(name), nil, &tag); err != nil {
+		if errors.Is(err, ErrPackageNotFound) {
+			return dockerHubTagResponse{}, fmt.Errorf("%w: no tag %s", ErrPackageNotFound, name)
+		}
+		return dockerHubTagResponse{}, err
+	}
+	return tag, nil
+}
+
+// findDigestTag returns the most recently pushed tag of the image repository at repoURL whose digest,
+// or the digest of one of its platform images, is digest.
+//
+// Docker Hub cannot look up an image by digest, so the tags list is searched, newest first, for at most
+// dockerHubMaxTagsPages pages.
+func (s *DockerHubService) findDigestTag(ctx context.Context, repoURL, digest string) (dockerHubTagResponse, error) {
+	for page := 1; page <= dockerHubMaxTagsPages; page++ {
+		params := url.Values{}
+		params.Set("page", strconv.Itoa(page))
+		params.Set("page_size", strconv.Itoa(dockerHubTagsPageSize))
+
+		var tags dockerHubTagsResponse
+		if err := getJSON(ctx, s.client, repoURL+"/tags?"+params.Encode(), nil, &tags); err != nil {
+			return dockerHubTagResponse{}, err
+		}
+		for _, tag := range tags.Results {
+			if tag.hasDigest(digest) {
+				return tag, nil
+			}
+		}
+		if tags.Next == nil || *tags.Next == "" {
+			break
+		}
+	}
+	return dockerHubTagResponse{}, fmt.Errorf("%w: no tag with digest %s", ErrPackageNotFound, digest)
+}
+
+// hasDigest reports whether digest is the digest of the tag or of one of its platform images.
+func (t dockerHubTagResponse) hasDigest(digest string) bool {
+	if strings.EqualFold(t.Digest, digest) {
+		return true
+	}
+	for _, image := range t.Images {
+		if strings.EqualFold(image.Digest, digest) {
+			return true
+		}
+	}
+	return false
+}
+
+// dockerHubRepository returns the namespace and name of the Docker Hub repository of a docker or oci purl.
+//
+// The repository of a docker purl is its namespace (library if empty) and name, unless the repository_url
+// qualifier names another registry. An oci purl has only a name: its repository_url qualifier must be
+// a Docker Hub repository (e.g., docker.io/library/debian), which provides the namespace
+// (see dockerHubOCINamespace).
+func dockerHubRepository(purl packageurl.PackageURL) (string, string, error) {
+	registry := purl.Qualifiers.Map()["repository_url"]
+	switch purl.Type {
+	case packageurl.TypeDocker:
+		if registry != "" && !isDockerHubRegistry(registry) {
+			return "", "", fmt.Errorf("%w: registry %s", ErrUnsupportedEcosystem, registry)
+		}
+		if purl.Namespace == "" {
+			return dockerHubOfficialNamespace, purl.Name, nil
+		}
+		return purl.Namespace, purl.Name, nil
+	case packageurl.TypeOCI:
+		if !isDockerHubRegistry(registry) {
+			return "", "", fmt.Errorf("%w: oci purls need a Docker Hub repository_url, got %q",
+				ErrUnsupportedEcosystem, registry)
+		}
+		return dockerHubOCINamespace(registry, purl.Name), purl.Name, nil
+	default:
+		return "", "", fmt.Errorf("%w: %s", ErrUnsupportedEcosystem, purl.Type)
+	}
+}
+
+// dockerHubOCINamespace returns the Docker Hub namespace of the oci image named name from its repository_url
+// qualifier: the first segment of a path with several segments (docker.io/octo/hello), or a single segment
+// (docker.io/octo) unless it is the image name itself (docker.io/nginx).
+//
+// Official images are in the library namespace, which is the default when the repository_url has no path,
+// or is the default Docker registry.
+func dockerHubOCINamespace(registry, name string) string {
+	host, path := splitRepositoryURL(registry)
+	if path == "" || host+"/"+path == dockerHubDefaultIndex {
+		return dockerHubOfficialNamespace
+	}
+	namespace, _, found := strings.Cut(path, "/")
+	if !found && namespace == name {
+		return dockerHubOfficialNamespace
+	}
+	return namespace
+}
+
+// splitRepositoryURL splits the repository_url qualifier of a container image purl, such as
+// docker.io/library/debian or https://index.docker.io/v1/, into its host and path.
+func splitRepositoryURL(repositoryURL string) (string, string) {
+	rest := repositoryURL
+	if _, afterScheme, ok := strings.Cut(rest, "://"); ok {
+		rest = afterScheme
+	}
+	host, path, _ := strings.Cut(rest, "/")
+	return host, strings.Trim(path, "/")
+}
+
+// isDockerHubRegistry reports whether the repository_url qualifier of a container image purl is Docker Hub.
+func isDockerHubRegistry(registry string) bool {
+	host, _ := splitRepositoryURL(registry)
+	switch host {
+	case "docker.io", "index.docker.io", "registry-1.docker.io", "hub.docker.com":
+		return true
+	default:
+		return false
+	}
+}
+
+// dockerHubPageURL returns the URL of the Docker Hub page of the image, which has its own path for
+// the official images.
+func dockerHubPageURL(namespace, name string) string {
+	if namespace == dockerHubOfficialNamespace {
+		return "https://hub.docker.com/_/" + url.PathEscape(name)
+	}
+	return "https://hub.docker.com/r/" + url.PathEscape(namespace) + "/" + url.PathEscape(name)
+}
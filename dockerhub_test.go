@@ -0,0 +1,342 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// TestNewDockerHubService tests the NewDockerHubService function.
+func TestNewDockerHubService(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default options", func(t *testing.T) {
+		t.Parallel()
+
+		service := NewDockerHubService(DockerHubServiceOptions{})
+
+		if service.baseURL != dockerHubBaseURL {
+			t.Errorf("baseURL = %q, want %q", service.baseURL, dockerHubBaseURL)
+		}
+		if service.client != http.DefaultClient {
+			t.Error("client should be http.DefaultClient when not provided")
+		}
+	})
+
+	t.Run("custom base URL", func(t *testing.T) {
+		t.Parallel()
+
+		customURL := "https://hub.example.com"
+		service := NewDockerHubService(DockerHubServiceOptions{
+			BaseURL: customURL,
+		})
+
+		if service.baseURL != customURL {
+			t.Errorf("baseURL = %q, want %q", service.baseURL, customURL)
+		}
+	})
+}
+
+// TestDockerHubService_GetPackageInfo tests the GetPackageInfo method.
+func TestDockerHubService_GetPackageInfo(t *testing.T) {
+	t.Parallel()
+
+	const nginxRepo = `{
+		"namespace": "library",
+		"name": "nginx",
+		"description": "Official build of Nginx.",
+		"pull_count": 1000000000,
+		"last_updated": "2024-06-01T10:00:00Z"
+	}`
+	pulls := 1000000000
+	repoUpdated := time.Date(2024, time.June, 1, 10, 0, 0, 0, time.UTC)
+	tagPushed := time.Date(2024, time.May, 20, 12, 0, 0, 0, time.UTC)
+
+	// mockResponse is the status code and body of the response to a path.
+	type mockResponse struct {
+		statusCode int
+		body       string
+	}
+
+	tests := []struct {
+		name      string
+		responses map[string]mockResponse
+		purl      string
+		want      PackageInfo
+		wantErr   error
+	}{
+		{
+			name: "official image with tag",
+			responses: map[string]mockResponse{
+				"/v2/repositories/library/nginx": {http.StatusOK, nginxRepo},
+				"/v2/repositories/library/nginx/tags/1.27": {http.StatusOK, `{
+					"name": "1.27",
+					"last_updated": "2024-06-01T10:00:00Z",
+					"tag_last_pushed": "2024-05-20T12:00:00Z"
+				}`},
+			},
+			purl: "pkg:docker/nginx@1.27",
+			want: PackageInfo{
+				Name:              "library/nginx",
+				Version:           "1.27",
+				Licenses:          []string{},
+				Homepage:          "https://hub.docker.com/_/nginx",
+				Description:       "Official build of Nginx.",
+				Ecosystem:         "docker",
+				DownloadCount:     &pulls,
+				LastPublishedDate: &tagPushed,
+			},
+		},
+		{
+			name: "image without version",
+			responses: map[string]mockResponse{
+				"/v2/repositories/library/nginx": {http.StatusOK, nginxRepo},
+			},
+			purl: "pkg:docker/library/nginx?repository_url=docker.io",
+			want: PackageInfo{
+				Name:              "library/nginx",
+				Licenses:          []string{},
+				Homepage:          "https://hub.docker.com/_/nginx",
+				Description:       "Official build of Nginx.",
+				Ecosystem:         "docker",
+				DownloadCount:     &pulls,
+				LastPublishedDate: &repoUpdated,
+			},
+		},
+		{
+			name: "user image without metadata",
+			responses: map[string]mockResponse{
+				"/v2/repositories/octo/hello":        {http.StatusOK, `{"namespace": "octo", "name": "hello"}`},
+				"/v2/repositories/octo/hello/tags/1": {http.StatusOK, `{"name": "1"}`},
+			},
+			purl: "pkg:docker/octo/hello@1",
+			want: PackageInfo{
+				Name:      "octo/hello",
+				Version:   "1",
+				Licenses:  []string{},
+				Homepage:  "https://hub.docker.com/r/octo/hello",
+				Ecosystem: "docker",
+			},
+		},
+		{
+			name: "missing tag",
+			responses: map[string]mockResponse{
+				"/v2/repositories/library/nginx":         {http.StatusOK, nginxRepo},
+				"/v2/repositories/library/nginx/tags/99": {http.StatusNotFound, `{"message": "object not found"}`},
+			},
+			purl:    "pkg:docker/nginx@99",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name: "missing image",
+			responses: map[string]mockResponse{
+				"/v2/repositories/octo/missing": {http.StatusNotFound, `{"message": "object not found"}`},
+			},
+			purl:    "pkg:docker/octo/missing@1",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name: "malformed JSON",
+			responses: map[string]mockResponse{
+				"/v2/repositories/library/nginx": {http.StatusOK, `{invalid json}`},
+			},
+			purl:    "pkg:docker/nginx",
+			wantErr: ErrInvalidResponse,
+		},
+		{
+			name:    "other registry",
+			purl:    "pkg:docker/octo/hello@1?repository_url=ghcr.io",
+			wantErr: ErrUnsupportedEcosystem,
+		},
+		{
+			name: "oci digest of a platform image",
+			responses: map[string]mockResponse{
+				"/v2/repositories/library/nginx": {http.StatusOK, nginxRepo},
+				"/v2/repositories/library/nginx/tags?page=1&page_size=100": {http.StatusOK, `{
+					"next": "https://hub.docker.com/v2/repositories/library/nginx/tags?page=2&page_size=100",
+					"results": [{"name": "latest", "digest": "sha256:aaa", "images": [{"digest": "sha256:aa1"}]}]
+				}`},
+				"/v2/repositories/library/nginx/tags?page=2&page_size=100": {http.StatusOK, `{
+					"next": null,
+					"results": [{
+						"name": "1.27",
+						"digest": "sha256:bbb",
+						"tag_last_pushed": "2024-05-20T12:00:00Z",
+						"images": [{"digest": "sha256:bb1"}, {"digest": "sha256:bb2"}]
+					}]
+				}`},
+			},
+			purl: "pkg:oci/nginx@sha256%3Abb2?repository_url=docker.io/library/nginx",
+			want: PackageInfo{
+				Name:              "library/nginx",
+				Version:           "sha256:bb2",
+				Licenses:          []string{},
+				Homepage:          "https://hub.docker.com/_/nginx",
+				Description:       "Official build of Nginx.",
+				Ecosystem:         "oci",
+				DownloadCount:     &pulls,
+				LastPublishedDate: &tagPushed,
+			},
+		},
+		{
+			name: "oci image without version",
+			responses: map[string]mockResponse{
+				"/v2/repositories/octo/hello": {http.StatusOK, `{"namespace": "octo", "name": "hello"}`},
+			},
+			purl: "pkg:oci/hello?repository_url=https://index.docker.io/octo/hello",
+			want: PackageInfo{
+				Name:      "octo/hello",
+				Licenses:  []string{},
+				Homepage:  "https://hub.docker.com/r/octo/hello",
+				Ecosystem: "oci",
+			},
+		},
+		{
+			name: "oci image with a namespace repository_url",
+			responses: map[string]mockResponse{
+				"/v2/repositories/octo/hello": {http.StatusOK, `{"namespace": "octo", "name": "hello"}`},
+			},
+			purl: "pkg:oci/hello?repository_url=docker.io/octo",
+			want: PackageInfo{
+				Name:      "octo/hello",
+				Licenses:  []string{},
+				Homepage:  "https://hub.docker.com/r/octo/hello",
+				Ecosystem: "oci",
+			},
+		},
+		{
+			name: "oci digest without tag",
+			responses: map[string]mockResponse{
+				"/v2/repositories/library/nginx": {http.StatusOK, nginxRepo},
+				"/v2/repositories/library/nginx/tags?page=1&page_size=100": {http.StatusOK, `{
+					"next": null,
+					"results": [{"name": "latest", "digest": "sha256:aaa"}]
+				}`},
+			},
+			purl:    "pkg:oci/nginx@sha256%3Accc?repository_url=docker.io/nginx",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name:    "oci image of another registry",
+			purl:    "pkg:oci/hello@sha256%3A0123?repository_url=ghcr.io/octo/hello",
+			wantErr: ErrUnsupportedEcosystem,
+		},
+		{
+			name:    "oci image without repository_url",
+			purl:    "pkg:oci/hello@sha256%3A0123",
+			wantErr: ErrUnsupportedEcosystem,
+		},
+		{
+			name:    "unsupported ecosystem",
+			purl:    "pkg:npm/hello@1.0.0",
+			wantErr: ErrUnsupportedEcosystem,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				response, ok := tt.responses[r.URL.RequestURI()]
+				if !ok {
+					t.Errorf("unexpected request %q", r.URL.RequestURI())
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(response.statusCode)
+				_, _ = w.Write([]byte(response.body))
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewDockerHubService(DockerHubServiceOptions{
+				BaseURL: server.URL,
+			})
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.GetPackageInfo(context.Background(), purl)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetPackageInfo() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+
+			if got.Name != tt.want.Name {
+				t.Errorf("GetPackageInfo() Name = %q, want %q", got.Name, tt.want.Name)
+			}
+			if got.Version != tt.want.Version {
+				t.Errorf("GetPackageInfo() Version = %q, want %q", got.Version, tt.want.Version)
+			}
+			if got.Ecosystem != tt.want.Ecosystem {
+				t.Errorf("GetPackageInfo() Ecosystem = %q, want %q", got.Ecosystem, tt.want.Ecosystem)
+			}
+			if !equalStringSlices(got.Licenses, tt.want.Licenses) {
+				t.Errorf("GetPackageInfo() Licenses = %v, want %v", got.Licenses, tt.want.Licenses)
+			}
+			if got.Homepage != tt.want.Homepage {
+				t.Errorf("GetPackageInfo() Homepage = %q, want %q", got.Homepage, tt.want.Homepage)
+			}
+			if got.RepositoryURL != tt.want.RepositoryURL {
+				t.Errorf("GetPackageInfo() RepositoryURL = %q, want %q", got.RepositoryURL, tt.want.RepositoryURL)
+			}
+			if got.Description != tt.want.Description {
+				t.Errorf("GetPackageInfo() Description = %q, want %q", got.Description, tt.want.Description)
+			}
+			if !equalIntPointers(got.DownloadCount, tt.want.DownloadCount) {
+				t.Errorf("GetPackageInfo() DownloadCount = %v, want %v", got.DownloadCount, tt.want.DownloadCount)
+			}
+			if !equalTimePointers(got.LastPublishedDate, tt.want.LastPublishedDate) {
+				t.Errorf("GetPackageInfo() LastPublishedDate = %v, want %v", got.LastPublishedDate, tt.want.LastPublishedDate)
+			}
+		})
+	}
+}
+
+// equalTimePointers reports whether a and b are both nil or point to the same instant.
+func equalTimePointers(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// TestDockerHubOCINamespace tests the dockerHubOCINamespace function.
+func TestDockerHubOCINamespace(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		registry string
+		want     string
+	}{
+		{registry: "docker.io", want: "library"},
+		{registry: "https://index.docker.io/v1/", want: "library"},
+		{registry: "docker.io/nginx", want: "library"},
+		{registry: "docker.io/library/nginx", want: "library"},
+		{registry: "docker.io/octo", want: "octo"},
+		{registry: "registry-1.docker.io/octo", want: "octo"},
+		{registry: "docker.io/octo/nginx", want: "octo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.registry, func(t *testing.T) {
+			t.Parallel()
+
+			if got := dockerHubOCINamespace(tt.registry, "nginx"); got != tt.want {
+				t.Errorf("dockerHubOCINamespace(%q, %q) = %q, want %q", tt.registry, "nginx", got, tt.want)
+			}
+		})
+	}
+}
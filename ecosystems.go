@@ -1,12 +1,20 @@
 package main
 
 import (
+	"cmp"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/package-url/packageurl-go"
 )
@@ -18,28 +26,81 @@ const (
 	ecosystemsBaseURL = "https://packages.ecosyste.ms"
 	// ecosystemsAPIPath is the API path for package lookup.
 	ecosystemsAPIPath = "/api/v1/packages/lookup"
+	// ecosystemsSearchAPIPath is the API path for package search.
+	ecosystemsSearchAPIPath = "/api/v1/packages/search"
+	// ecosystemsRegistriesAPIPath is the API path for registry-specific package endpoints.
+	ecosystemsRegistriesAPIPath = "/api/v1/registries"
+	// ecosystemsMaxRetries is the maximum number of retries of a rate limited request.
+	ecosystemsMaxRetries = 1
+	// ecosystemsMaxPages is the maximum number of pages followed, guarding against endless pagination.
+	ecosystemsMaxPages = 100
+	// ecosystemsMinAPIVersion is the default minimum supported version of the Ecosystems API.
+	ecosystemsMinAPIVersion = "1.0"
+	// ecosystemsAPIVersionHeader is the response header with the version of the Ecosystems API.
+	ecosystemsAPIVersionHeader = "API-Version"
 )
 
 // EcosystemsService is the service for the Ecosystems API.
 type EcosystemsService struct {
 	baseURL string
-	client  *http.Client
-	email   string
+	// typeBaseURLs maps lowercase purl types to the base URL used for them instead of baseURL.
+	typeBaseURLs map[string]string
+	client       *http.Client
+	email        string
+	userAgent    string
+	logger       *slog.Logger
+	warnings     io.Writer
+
+	preferRegistryEndpoint bool
+	requestTimeout         time.Duration
+
+	minAPIVersion     string
+	apiVersionChecked sync.Once
 }
 
-var _ Service = (*EcosystemsService)(nil)
+var (
+	_ Service         = (*EcosystemsService)(nil)
+	_ VersionLister   = (*EcosystemsService)(nil)
+	_ PackageSearcher = (*EcosystemsService)(nil)
+	_ MatchLister     = (*EcosystemsService)(nil)
+)
 
 // EcosystemsServiceOptions are the options for the EcosystemsService.
 type EcosystemsServiceOptions struct {
 	// BaseURL is the base URL for the Ecosystems API.
 	// If empty, defaults to the public Ecosystems API.
 	BaseURL string
+	// EcosystemBaseURLOverrides maps purl types (e.g., "maven") to the base URL of the Ecosystems API
+	// serving them, for deployments where ecosystems are served by different instances.
+	// Lookups of purls of other types, and searches, use BaseURL.
+	EcosystemBaseURLOverrides map[string]string
 	// Client is the HTTP client to use for the Ecosystems API.
 	// If nil, defaults to http.DefaultClient.
 	Client *http.Client
 	// Email is the email address for the polite pool.
 	// If empty, requests will not include polite pool identification.
 	Email string
+	// UserAgent is the User-Agent header sent with each request.
+	// If empty, defaults to purlinfo/<version> (+https://github.com/boringbin/purlinfo).
+	UserAgent string
+	// PreferRegistryEndpoint looks up purls with a namespace (e.g., Maven, scoped npm packages)
+	// with the registry-specific endpoint, which may return richer metadata.
+	// The generic lookup endpoint remains the fallback.
+	PreferRegistryEndpoint bool
+	// RequestTimeout is the timeout of each request to the API, including retries and reading the response.
+	// If the context passed to the methods has an earlier deadline, that deadline applies instead.
+	// If zero, only the context deadline applies.
+	RequestTimeout time.Duration
+	// MinAPIVersion is the minimum supported version of the API, as returned in the API-Version header.
+	// A warning is written to Warnings if the API returns a lower version.
+	// If empty, defaults to 1.0.
+	MinAPIVersion string
+	// Logger is the debug logger.
+	// If nil, nothing is logged.
+	Logger *slog.Logger
+	// Warnings receives the warnings about the API, such as an unsupported API version.
+	// If nil, warnings are discarded.
+	Warnings io.Writer
 }
 
 // NewEcosystemsService creates a new EcosystemsService.
@@ -54,11 +115,44 @@ func NewEcosystemsService(opts EcosystemsServiceOptions) *EcosystemsService {
 	if client == nil {
 		client = http.DefaultClient
 	}
+	// Default to the purlinfo User-Agent.
+	ua := opts.UserAgent
+	if ua == "" {
+		ua = userAgent()
+	}
+	// Default to the minimum version this client was written against.
+	minAPIVersion := opts.MinAPIVersion
+	if minAPIVersion == "" {
+		minAPIVersion = ecosystemsMinAPIVersion
+	}
+	// Default to discarding logs and warnings.
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	warnings := opts.Warnings
+	if warnings == nil {
+		warnings = io.Discard
+	}
+	// purl types are case-insensitive
+	typeBaseURLs := make(map[string]string, len(opts.EcosystemBaseURLOverrides))
+	for purlType, typeBaseURL := range opts.EcosystemBaseURLOverrides {
+		typeBaseURLs[strings.ToLower(purlType)] = typeBaseURL
+	}
 
 	return &EcosystemsService{
-		baseURL: baseURL,
-		client:  client,
-		email:   opts.Email,
+		baseURL:      baseURL,
+		typeBaseURLs: typeBaseURLs,
+		client:       client,
+		email:        opts.Email,
+		userAgent:    ua,
+		logger:       logger,
+		warnings:     warnings,
+
+		preferRegistryEndpoint: opts.PreferRegistryEndpoint,
+		requestTimeout:         opts.RequestTimeout,
+
+		minAPIVersion: minAPIVersion,
 	}
 }
 
@@ -71,6 +165,78 @@ type ecosystemsPackagesLookupResponse struct {
 	RepositoryURL       *string  `json:"repository_url"`
 	Description         *string  `json:"description"`
 	DocumentationURL    *string  `json:"documentation_url"`
+	VersionsURL         *string  `json:"versions_url"`
+	Ecosystem           string   `json:"ecosystem"`
+	// RepoMetadata is the metadata of the repository of the package (null if the repository is not indexed).
+	RepoMetadata *ecosystemsRepoMetadata `json:"repo_metadata"`
+}
+
+// ecosystemsRepoMetadata is the metadata of the repository of a package, as indexed by repos.ecosyste.ms.
+type ecosystemsRepoMetadata struct {
+	// CommitStats are the commit statistics of the repository (null if not computed yet).
+	CommitStats *ecosystemsCommitStats `json:"commit_stats"`
+	// PushedAt is the time of the last push to the repository, in RFC 3339 format.
+	PushedAt *time.Time `json:"pushed_at"`
+	// ForksCount is the number of forks of the repository.
+	ForksCount *int `json:"forks_count"`
+	// SubscribersCount is the number of watchers of the repository (GitHub's watchers_count is the star count).
+	SubscribersCount *int `json:"subscribers_count"`
+}
+
+// ecosystemsCommitStats are the commit statistics of a repository, as computed by commits.ecosyste.ms.
+type ecosystemsCommitStats struct {
+	// TotalCommitters is the number of distinct commit authors.
+	TotalCommitters *int `json:"total_committers"`
+}
+
+// validate checks that the response contains the fields required to build a PackageInfo.
+//
+// The latest release number is not required, since packages without any releases have none.
+func (r ecosystemsPackagesLookupResponse) validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("%w: missing package name", ErrInvalidResponse)
+	}
+	return nil
+}
+
+// packageInfo converts the response to a PackageInfo for the given ecosystem.
+//
+// Licenses is never nil, even if the API returns null for the licenses.
+// ContributorCount, LastCommitDate, ForksCount and WatchersCount come from the repository metadata, if known.
+func (r ecosystemsPackagesLookupResponse) packageInfo(ecosystem string) PackageInfo {
+	licenses := r.NormalizedLicenses
+	if licenses == nil {
+		licenses = []string{}
+	}
+
+	var repo ecosystemsRepoMetadata
+	if r.RepoMetadata != nil {
+		repo = *r.RepoMetadata
+	}
+	var contributorCount *int
+	if repo.CommitStats != nil {
+		contributorCount = repo.CommitStats.TotalCommitters
+	}
+
+	return PackageInfo{
+		Name:             r.Name,
+		Version:          r.LatestReleaseNumber,
+		Licenses:         licenses,
+		Homepage:         stringValue(r.Homepage),
+		RepositoryURL:    stringValue(r.RepositoryURL),
+		Description:      stringValue(r.Description),
+		Ecosystem:        ecosystem,
+		DocumentationURL: stringValue(r.DocumentationURL),
+		ContributorCount: contributorCount,
+		LastCommitDate:   repo.PushedAt,
+		ForksCount:       repo.ForksCount,
+		WatchersCount:    repo.SubscribersCount,
+	}
+}
+
+// ecosystemsVersionResponse is a single entry of the response from the Ecosystems versions endpoint.
+type ecosystemsVersionResponse struct {
+	Number string `json:"number"`
 }
 
 // stringValue converts a *string to string, returning empty string if nil.
@@ -83,66 +249,375 @@ func stringValue(s *string) string {
 
 // GetPackageInfo returns the information about a package.
 func (s *EcosystemsService) GetPackageInfo(ctx context.Context, purl packageurl.PackageURL) (PackageInfo, error) {
-	apiURL := fmt.Sprintf("%s%s?purl=%s", s.baseURL, ecosystemsAPIPath, url.QueryEscape(purl.String()))
+	result, err := s.lookup(ctx, purl)
+	if err != nil {
+		return PackageInfo{}, err
+	}
+
+	// Guard against silently returning an empty PackageInfo if the API schema changes
+	if validateErr := result.validate(); validateErr != nil {
+		return PackageInfo{}, validateErr
+	}
+
+	// Convert the response to the PackageInfo struct
+	return result.packageInfo(purl.Type), nil
+}
+
+// ListMatches returns the information about every package matching the purl.
+//
+// GetPackageInfo only returns the first of them. The lookup endpoint is always used, even if
+// PreferRegistryEndpoint is set, since a registry endpoint only knows about its own registry.
+func (s *EcosystemsService) ListMatches(ctx context.Context, purl packageurl.PackageURL) ([]PackageInfo, error) {
+	results, err := s.lookupAll(ctx, purl, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]PackageInfo, 0, len(results))
+	for _, result := range results {
+		if validateErr := result.validate(); validateErr != nil {
+			return nil, validateErr
+		}
+		infos = append(infos, result.packageInfo(purl.Type))
+	}
+
+	return infos, nil
+}
+
+// SearchPackages returns up to limit packages matching the query.
+//
+// The ecosystem of each result is the Ecosystems name (e.g., `npm`, `pypi`, `rubygems`).
+func (s *EcosystemsService) SearchPackages(
+	ctx context.Context,
+	query string,
+	ecosystem string,
+	limit int,
+) ([]PackageInfo, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	if ecosystem != "" {
+		params.Set("ecosystem", ecosystem)
+	}
+	if limit > 0 {
+		params.Set("per_page", strconv.Itoa(limit))
+	}
+	apiURL := fmt.Sprintf("%s%s?%s", s.baseURL, ecosystemsSearchAPIPath, params.Encode())
+
+	results, err := getAllPages[ecosystemsPackagesLookupResponse](ctx, s, apiURL, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	packages := make([]PackageInfo, 0, len(results))
+	for _, result := range results {
+		packages = append(packages, result.packageInfo(result.Ecosystem))
+	}
+
+	return packages, nil
+}
+
+// ListVersions returns all available versions of a package, sorted from oldest to newest.
+func (s *EcosystemsService) ListVersions(ctx context.Context, purl packageurl.PackageURL) ([]string, error) {
+	result, err := s.lookup(ctx, purl)
+	if err != nil {
+		return nil, err
+	}
+
+	versionsURL := stringValue(result.VersionsURL)
+	if versionsURL == "" {
+		return nil, fmt.Errorf("%w: missing versions_url", ErrInvalidResponse)
+	}
+
+	versions, err := getAllPages[ecosystemsVersionResponse](ctx, s, versionsURL, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	numbers := make([]string, 0, len(versions))
+	for _, v := range versions {
+		numbers = append(numbers, v.Number)
+	}
+	sortVersions(numbers)
+
+	return numbers, nil
+}
+
+// lookup returns the first result of the package lookup endpoint for a purl.
+//
+// With preferRegistryEndpoint, the registry-specific endpoint is tried first for purls with a namespace.
+func (s *EcosystemsService) lookup(
+	ctx context.Context,
+	purl packageurl.PackageURL,
+) (ecosystemsPackagesLookupResponse, error) {
+	if s.preferRegistryEndpoint && purl.Namespace != "" {
+		result, err := s.registryLookup(ctx, purl)
+		// Fall back to the lookup endpoint unless the registry endpoint failed for another reason
+		if err == nil || (!errors.Is(err, ErrPackageNotFound) && !errors.Is(err, ErrUnsupportedEcosystem)) {
+			return result, err
+		}
+	}
+
+	// Only the first result is used
+	results, err := s.lookupAll(ctx, purl, 1)
+	if err != nil {
+		return ecosystemsPackagesLookupResponse{}, err
+	}
+	return results[0], nil
+}
 
+// lookupAll returns up to maxResults packages from the lookup endpoint for a purl, or all of them
+// if maxResults is 0.
+//
+// The lookup endpoint returns several packages when the purl matches packages in several registries,
+// for example for packages mirrored across registries.
+func (s *EcosystemsService) lookupAll(
+	ctx context.Context,
+	purl packageurl.PackageURL,
+	maxResults int,
+) ([]ecosystemsPackagesLookupResponse, error) {
+	apiURL := fmt.Sprintf("%s%s?purl=%s", s.purlBaseURL(purl), ecosystemsAPIPath, url.QueryEscape(purl.String()))
+
+	// Parse the response (it's an array)
+	results, err := getAllPages[ecosystemsPackagesLookupResponse](ctx, s, apiURL, maxResults)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if we got any results
+	if len(results) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrPackageNotFound, purl.String())
+	}
+
+	return results, nil
+}
+
+// registryLookup returns the package from the registry-specific endpoint for a purl.
+func (s *EcosystemsService) registryLookup(
+	ctx context.Context,
+	purl packageurl.PackageURL,
+) (ecosystemsPackagesLookupResponse, error) {
+	registry, name, ok := ecosystemsRegistryPackage(purl)
+	if !ok {
+		return ecosystemsPackagesLookupResponse{}, fmt.Errorf("%w: no registry for %s", ErrUnsupportedEcosystem, purl.Type)
+	}
+	apiURL := fmt.Sprintf("%s%s/%s/packages/%s",
+		s.purlBaseURL(purl), ecosystemsRegistriesAPIPath, url.PathEscape(registry), url.PathEscape(name))
+
+	var result ecosystemsPackagesLookupResponse
+	if _, err := s.getPage(ctx, apiURL, &result); err != nil {
+		return ecosystemsPackagesLookupResponse{}, err
+	}
+	return result, nil
+}
+
+// purlBaseURL returns the base URL of the API serving packages of the purl type.
+func (s *EcosystemsService) purlBaseURL(purl packageurl.PackageURL) string {
+	if typeBaseURL, ok := s.typeBaseURLs[strings.ToLower(purl.Type)]; ok {
+		return typeBaseURL
+	}
+	return s.baseURL
+}
+
+// ecosystemsRegistryPackage returns the Ecosystems registry and package name of a purl with a namespace.
+func ecosystemsRegistryPackage(purl packageurl.PackageURL) (string, string, bool) {
+	name := purl.Namespace + "/" + purl.Name
+	switch purl.Type {
+	case packageurl.TypeMaven:
+		// Maven packages are named groupId:artifactId
+		return "repo1.maven.org", purl.Namespace + ":" + purl.Name, true
+	case packageurl.TypeNPM:
+		return "npmjs.org", name, true
+	case packageurl.TypeGolang:
+		return "proxy.golang.org", name, true
+	case packageurl.TypeDocker:
+		return "hub.docker.com", name, true
+	case packageurl.TypeComposer:
+		return "packagist.org", name, true
+	default:
+		return "", "", false
+	}
+}
+
+// newRequest creates a GET request for the Ecosystems API.
+func (s *EcosystemsService) newRequest(ctx context.Context, apiURL string) (*http.Request, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
 	if err != nil {
-		return PackageInfo{}, fmt.Errorf("failed to create HTTP request: %w", err)
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
 	// Set User-Agent header
-	userAgent := fmt.Sprintf("purlinfo/%s", version)
+	ua := s.userAgent
 	if s.email != "" {
 		// See https://ecosyste.ms/api
-		userAgent = fmt.Sprintf("purlinfo/%s (mailto:%s)", version, s.email)
+		ua = fmt.Sprintf("%s (mailto:%s)", ua, s.email)
+		// Also identify the user with the From header (RFC 9110, section 10.1.2).
+		req.Header.Set("From", s.email)
 	}
-	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("User-Agent", ua)
+	// Setting Accept-Encoding ourselves disables the transparent decompression of
+	// http.Transport, so gzip responses are decompressed in getPage.
+	req.Header.Set("Accept-Encoding", "gzip")
 
-	response, err := s.client.Do(req)
+	return req, nil
+}
+
+// getAllPages makes GET requests to the Ecosystems API and decodes the JSON arrays of the response
+// and of the following pages, as linked by the Link header.
+//
+// It stops once maxResults results have been collected, unless maxResults is 0.
+func getAllPages[T any](ctx context.Context, s *EcosystemsService, apiURL string, maxResults int) ([]T, error) {
+	var results []T
+	for page := 0; apiURL != "" && page < ecosystemsMaxPages; page++ {
+		var pageResults []T
+		nextURL, err := s.getPage(ctx, apiURL, &pageResults)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, pageResults...)
+
+		// Don't rely on the API honoring per_page
+		if maxResults > 0 && len(results) >= maxResults {
+			return results[:maxResults], nil
+		}
+		if len(pageResults) == 0 {
+			break
+		}
+		apiURL = nextURL
+	}
+
+	return results, nil
+}
+
+// getPage makes a GET request to the Ecosystems API and decodes the JSON response body into v.
+//
+// It returns the URL of the next page, or an empty string if there is none.
+func (s *EcosystemsService) getPage(ctx context.Context, apiURL string, v any) (string, error) {
+	// The shorter of the context deadline and the request timeout wins
+	if s.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.requestTimeout)
+		defer cancel()
+	}
+
+	response, err := s.do(ctx, apiURL)
 	if err != nil {
-		return PackageInfo{}, fmt.Errorf("failed to make HTTP request: %w", err)
+		return "", err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		switch response.StatusCode {
-		case http.StatusNotFound:
-			return PackageInfo{}, fmt.Errorf("%w: HTTP 404", ErrPackageNotFound)
-		case http.StatusTooManyRequests:
-			return PackageInfo{}, errors.New("rate limited by API: HTTP 429")
-		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
-			return PackageInfo{}, fmt.Errorf("API service unavailable: HTTP %d", response.StatusCode)
-		default:
-			return PackageInfo{}, fmt.Errorf("API error: HTTP %d", response.StatusCode)
+		return "", statusError(response.StatusCode)
+	}
+
+	body := io.Reader(response.Body)
+	if strings.EqualFold(response.Header.Get("Content-Encoding"), "gzip") {
+		gzipReader, gzipErr := gzip.NewReader(response.Body)
+		if gzipErr != nil {
+			return "", fmt.Errorf("%w: %w", ErrInvalidResponse, gzipErr)
 		}
+		defer gzipReader.Close()
+		body = gzipReader
 	}
 
-	// Parse the response (it's an array)
-	var results []ecosystemsPackagesLookupResponse
-	err = json.NewDecoder(response.Body).Decode(&results)
-	if err != nil {
-		return PackageInfo{}, fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+	if decodeErr := json.NewDecoder(body).Decode(v); decodeErr != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidResponse, decodeErr)
 	}
 
-	// Check if we got any results
-	if len(results) == 0 {
-		return PackageInfo{}, fmt.Errorf("%w: %s", ErrPackageNotFound, purl.String())
+	return nextPageURL(response.Header, response.Request.URL), nil
+}
+
+// checkAPIVersion logs the API version returned by the API, and warns if it is below the minimum supported
+// version. Only the first response with an API version is checked, so that the warning is printed once.
+func (s *EcosystemsService) checkAPIVersion(apiVersion string) {
+	if apiVersion == "" {
+		return
 	}
 
-	// Get the first result
-	result := results[0]
+	s.apiVersionChecked.Do(func() {
+		s.logger.Debug("ecosystems API version", "version", apiVersion)
 
-	// Convert the response to the PackageInfo struct
-	packageInfo := PackageInfo{
-		Name:             result.Name,
-		Version:          result.LatestReleaseNumber,
-		Licenses:         result.NormalizedLicenses,
-		Homepage:         stringValue(result.Homepage),
-		RepositoryURL:    stringValue(result.RepositoryURL),
-		Description:      stringValue(result.Description),
-		Ecosystem:        purl.Type,
-		DocumentationURL: stringValue(result.DocumentationURL),
-	}
-
-	return packageInfo, nil
+		order, ok := compareAPIVersions(apiVersion, s.minAPIVersion)
+		if !ok {
+			s.logger.Debug("cannot compare API versions", "version", apiVersion, "minimum", s.minAPIVersion)
+			return
+		}
+		if order < 0 {
+			fmt.Fprintf(s.warnings, "Warning: API version %s is below minimum supported %s. Some fields may be missing.\n",
+				apiVersion, s.minAPIVersion)
+		}
+	})
+}
+
+// compareAPIVersions compares two dotted numeric API versions (e.g., 1, 1.1, v2.0), returning -1, 0 or 1
+// as with cmp.Compare, or false if either version cannot be parsed. Missing components count as 0.
+func compareAPIVersions(a, b string) (int, bool) {
+	aParts, aOK := parseAPIVersion(a)
+	bParts, bOK := parseAPIVersion(b)
+	if !aOK || !bOK {
+		return 0, false
+	}
+
+	for i := range max(len(aParts), len(bParts)) {
+		var aPart, bPart int
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+		if c := cmp.Compare(aPart, bPart); c != 0 {
+			return c, true
+		}
+	}
+	return 0, true
+}
+
+// parseAPIVersion parses a dotted numeric API version, with an optional v prefix, into its components.
+func parseAPIVersion(version string) ([]int, bool) {
+	fields := strings.Split(strings.TrimPrefix(strings.TrimSpace(version), "v"), ".")
+	parts := make([]int, 0, len(fields))
+	for _, field := range fields {
+		part, err := strconv.Atoi(field)
+		if err != nil || part < 0 {
+			return nil, false
+		}
+		parts = append(parts, part)
+	}
+	return parts, true
+}
+
+// do makes a GET request to the Ecosystems API.
+//
+// When rate limited with a Retry-After header, it waits as instructed and retries,
+// unless the wait would exceed the context deadline.
+func (s *EcosystemsService) do(ctx context.Context, apiURL string) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := s.newRequest(ctx, apiURL)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := s.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to make HTTP request: %w", err)
+		}
+		s.checkAPIVersion(response.Header.Get(ecosystemsAPIVersionHeader))
+
+		if response.StatusCode != http.StatusTooManyRequests || attempt >= ecosystemsMaxRetries {
+			return response, nil
+		}
+
+		delay, ok := parseRetryAfter(response.Header.Get("Retry-After"), time.Now())
+		_ = response.Body.Close()
+		if !ok {
+			return nil, statusError(http.StatusTooManyRequests)
+		}
+		if deadline, hasDeadline := ctx.Deadline(); hasDeadline && time.Now().Add(delay).After(deadline) {
+			return nil, fmt.Errorf("%w (retry after %s exceeds timeout)", statusError(http.StatusTooManyRequests), delay)
+		}
+
+		if waitErr := sleepContext(ctx, delay); waitErr != nil {
+			return nil, waitErr
+		}
+	}
 }
@@ -0,0 +1,59 @@
+//go:build integration && slow
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// TestEcosystemsService_Integration_ManyPackages looks up packages from many ecosystems one after another,
+// as a multi-package run would.
+func TestEcosystemsService_Integration_ManyPackages(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	purls := []string{
+		"pkg:npm/lodash@4.17.21",
+		"pkg:npm/%40types/node@18.0.0",
+		"pkg:pypi/requests@2.28.0",
+		"pkg:pypi/django@4.2.0",
+		"pkg:cargo/serde@1.0.188",
+		"pkg:gem/rails@7.0.0",
+		"pkg:maven/com.google.guava/guava@32.1.2-jre",
+		"pkg:nuget/Newtonsoft.Json@13.0.3",
+		"pkg:golang/golang.org/x/text@v0.13.0",
+		"pkg:composer/laravel/framework@v10.0.0",
+	}
+
+	service := NewEcosystemsService(EcosystemsServiceOptions{})
+
+	// The whole run shares one timeout, like a single purlinfo invocation would.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	total := time.Now()
+	for _, purlString := range purls {
+		purl, err := packageurl.FromString(purlString)
+		if err != nil {
+			t.Fatalf("failed to parse purl %q: %v", purlString, err)
+		}
+
+		start := time.Now()
+		got, err := service.GetPackageInfo(ctx, purl)
+		if err != nil {
+			t.Errorf("GetPackageInfo(%s) error = %v", purlString, err)
+			continue
+		}
+		t.Logf("GetPackageInfo(%s) took %s: %s v%s", purlString, time.Since(start), got.Name, got.Version)
+
+		if got.Name == "" {
+			t.Errorf("GetPackageInfo(%s) Name is empty", purlString)
+		}
+	}
+	t.Logf("looked up %d packages in %s", len(purls), time.Since(total))
+}
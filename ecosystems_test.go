@@ -1,9 +1,18 @@
 package main
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -160,6 +169,43 @@ func TestEcosystemsService_GetPackageInfo(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "null licenses and optional fields",
+			mockResponse: `[{
+				"name": "testpkg",
+				"latest_release_number": "1.0.0",
+				"normalized_licenses": null,
+				"homepage": null,
+				"repository_url": null,
+				"description": null,
+				"documentation_url": null
+			}]`,
+			mockStatusCode: http.StatusOK,
+			purl:           "pkg:npm/testpkg@1.0.0",
+			want: PackageInfo{
+				Name:      "testpkg",
+				Version:   "1.0.0",
+				Licenses:  []string{},
+				Ecosystem: "npm",
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing licenses and optional fields",
+			mockResponse: `[{
+				"name": "testpkg",
+				"latest_release_number": "1.0.0"
+			}]`,
+			mockStatusCode: http.StatusOK,
+			purl:           "pkg:npm/testpkg@1.0.0",
+			want: PackageInfo{
+				Name:      "testpkg",
+				Version:   "1.0.0",
+				Licenses:  []string{},
+				Ecosystem: "npm",
+			},
+			wantErr: false,
+		},
 		{
 			name:           "empty results",
 			mockResponse:   `[]`,
@@ -200,6 +246,14 @@ func TestEcosystemsService_GetPackageInfo(t *testing.T) {
 			wantErr:        true,
 			errContains:    "invalid API response",
 		},
+		{
+			name:           "missing package name",
+			mockResponse:   `[{"latest_release_number": "1.0.0", "normalized_licenses": ["MIT"]}]`,
+			mockStatusCode: http.StatusOK,
+			purl:           "pkg:npm/test@1.0.0",
+			wantErr:        true,
+			errContains:    "missing package name",
+		},
 		{
 			name:           "HTTP 429 rate limit error",
 			mockResponse:   `{"error": "too many requests"}`,
@@ -301,6 +355,9 @@ func TestEcosystemsService_GetPackageInfo(t *testing.T) {
 			if !equalStringSlices(got.Licenses, tt.want.Licenses) {
 				t.Errorf("GetPackageInfo() Licenses = %v, want %v", got.Licenses, tt.want.Licenses)
 			}
+			if got.Licenses == nil {
+				t.Error("GetPackageInfo() Licenses is nil, want non-nil slice")
+			}
 			if got.Homepage != tt.want.Homepage {
 				t.Errorf(
 					"GetPackageInfo() Homepage = %v, want %v",
@@ -333,6 +390,207 @@ func TestEcosystemsService_GetPackageInfo(t *testing.T) {
 	}
 }
 
+// TestEcosystemsService_GetPackageInfo_UppercaseType tests that purl types are case-insensitive.
+func TestEcosystemsService_GetPackageInfo_UppercaseType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		purl          string
+		wantPURL      string
+		wantEcosystem string
+	}{
+		{
+			name:          "uppercase scheme and type",
+			purl:          "PKG:NPM/lodash@4.17.21",
+			wantPURL:      "pkg:npm/lodash@4.17.21",
+			wantEcosystem: "npm",
+		},
+		{
+			name:          "mixed case type",
+			purl:          "pkg:PyPI/requests@2.28.0",
+			wantPURL:      "pkg:pypi/requests@2.28.0",
+			wantEcosystem: "pypi",
+		},
+		{
+			name:          "mixed case type with namespace",
+			purl:          "pkg:Maven/org.apache.commons/commons-lang3@3.12.0",
+			wantPURL:      "pkg:maven/org.apache.commons/commons-lang3@3.12.0",
+			wantEcosystem: "maven",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// The API must receive the normalized purl
+				if got := r.URL.Query().Get("purl"); got != tt.wantPURL {
+					t.Errorf("purl query parameter = %q, want %q", got, tt.wantPURL)
+				}
+				_, _ = w.Write([]byte(`[{"name":"test","latest_release_number":"1.0.0","normalized_licenses":[]}]`))
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewEcosystemsService(EcosystemsServiceOptions{
+				BaseURL: server.URL,
+			})
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.GetPackageInfo(context.Background(), purl)
+			if err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+			if got.Ecosystem != tt.wantEcosystem {
+				t.Errorf("GetPackageInfo() Ecosystem = %q, want %q", got.Ecosystem, tt.wantEcosystem)
+			}
+		})
+	}
+}
+
+// TestEcosystemsService_GetPackageInfo_RepoMetadata tests the fields populated from the repository metadata.
+func TestEcosystemsService_GetPackageInfo_RepoMetadata(t *testing.T) {
+	t.Parallel()
+
+	contributors, forks, watchers := 342, 7000, 880
+	pushedAt := time.Date(2023, time.November, 15, 8, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		repoMetadata         string
+		wantContributorCount *int
+		wantLastCommitDate   *time.Time
+		wantForksCount       *int
+		wantWatchersCount    *int
+	}{
+		{
+			name: "commit stats and last push",
+			repoMetadata: `{"commit_stats": {"total_commits": 8090, "total_committers": 342},` +
+				` "pushed_at": "2023-11-15T08:30:00.000Z"}`,
+			wantContributorCount: &contributors,
+			wantLastCommitDate:   &pushedAt,
+		},
+		{
+			name:              "forks and watchers",
+			repoMetadata:      `{"stargazers_count": 59000, "forks_count": 7000, "subscribers_count": 880}`,
+			wantForksCount:    &forks,
+			wantWatchersCount: &watchers,
+		},
+		{
+			name:         "no commit stats nor last push",
+			repoMetadata: `{"commit_stats": null, "pushed_at": null}`,
+		},
+		{
+			name:         "repository not indexed",
+			repoMetadata: `null`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`[{"name":"lodash","latest_release_number":"4.17.21","normalized_licenses":["MIT"],` +
+					`"repo_metadata":` + tt.repoMetadata + `}]`))
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewEcosystemsService(EcosystemsServiceOptions{
+				BaseURL: server.URL,
+			})
+
+			purl, err := packageurl.FromString("pkg:npm/lodash@4.17.21")
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.GetPackageInfo(context.Background(), purl)
+			if err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+			if !equalIntPointers(got.ContributorCount, tt.wantContributorCount) {
+				t.Errorf("GetPackageInfo() ContributorCount = %v, want %v", got.ContributorCount, tt.wantContributorCount)
+			}
+			if (got.LastCommitDate == nil) != (tt.wantLastCommitDate == nil) ||
+				(got.LastCommitDate != nil && !got.LastCommitDate.Equal(*tt.wantLastCommitDate)) {
+				t.Errorf("GetPackageInfo() LastCommitDate = %v, want %v", got.LastCommitDate, tt.wantLastCommitDate)
+			}
+			if !equalIntPointers(got.ForksCount, tt.wantForksCount) {
+				t.Errorf("GetPackageInfo() ForksCount = %v, want %v", got.ForksCount, tt.wantForksCount)
+			}
+			if !equalIntPointers(got.WatchersCount, tt.wantWatchersCount) {
+				t.Errorf("GetPackageInfo() WatchersCount = %v, want %v", got.WatchersCount, tt.wantWatchersCount)
+			}
+		})
+	}
+}
+
+// TestEcosystemsService_GetPackageInfo_Maven tests the requests for a Maven purl, whose namespace is the groupId
+// and name the artifactId.
+func TestEcosystemsService_GetPackageInfo_Maven(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                   string
+		preferRegistryEndpoint bool
+		wantRequestURI         string
+	}{
+		{
+			name:           "lookup endpoint",
+			wantRequestURI: ecosystemsAPIPath + "?purl=pkg%3Amaven%2Forg.apache.commons%2Fcommons-lang3%403.12.0",
+		},
+		{
+			name:                   "registry endpoint",
+			preferRegistryEndpoint: true,
+			wantRequestURI:         "/api/v1/registries/repo1.maven.org/packages/org.apache.commons:commons-lang3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.RequestURI != tt.wantRequestURI {
+					t.Errorf("request URI = %q, want %q", r.RequestURI, tt.wantRequestURI)
+				}
+				body := `{"name":"org.apache.commons:commons-lang3","latest_release_number":"3.14.0",` +
+					`"normalized_licenses":["Apache-2.0"]}`
+				if r.URL.Path == ecosystemsAPIPath {
+					body = "[" + body + "]"
+				}
+				_, _ = w.Write([]byte(body))
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewEcosystemsService(EcosystemsServiceOptions{
+				BaseURL:                server.URL,
+				PreferRegistryEndpoint: tt.preferRegistryEndpoint,
+			})
+
+			purl, err := packageurl.FromString("pkg:maven/org.apache.commons/commons-lang3@3.12.0")
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.GetPackageInfo(context.Background(), purl)
+			if err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+			if got.Name != "org.apache.commons:commons-lang3" || got.Ecosystem != "maven" {
+				t.Errorf("GetPackageInfo() Name = %q, Ecosystem = %q, want %q, %q",
+					got.Name, got.Ecosystem, "org.apache.commons:commons-lang3", "maven")
+			}
+		})
+	}
+}
+
 // TestEcosystemsService_GetPackageInfo_ContextCancellation tests the GetPackageInfo method with a cancelled context.
 func TestEcosystemsService_GetPackageInfo_ContextCancellation(t *testing.T) {
 	t.Parallel()
@@ -393,6 +651,84 @@ func TestEcosystemsService_GetPackageInfo_Timeout(t *testing.T) {
 	}
 }
 
+// TestEcosystemsService_RequestTimeout tests that the shorter of the request timeout and
+// the context deadline applies.
+func TestEcosystemsService_RequestTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		requestTimeout time.Duration
+		contextTimeout time.Duration
+		wantErr        bool
+	}{
+		{
+			name:           "request timeout shorter than context deadline",
+			requestTimeout: 50 * time.Millisecond,
+			contextTimeout: 5 * time.Second,
+			wantErr:        true,
+		},
+		{
+			name:           "context deadline shorter than request timeout",
+			requestTimeout: 5 * time.Second,
+			contextTimeout: 50 * time.Millisecond,
+			wantErr:        true,
+		},
+		{
+			name:           "both longer than the response time",
+			requestTimeout: 5 * time.Second,
+			contextTimeout: 5 * time.Second,
+		},
+		{
+			name:           "no request timeout",
+			contextTimeout: 5 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Create a server that delays response.
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(200 * time.Millisecond):
+				case <-r.Context().Done():
+					return
+				}
+				_, _ = w.Write([]byte(`[{"name":"test","latest_release_number":"1.0.0","normalized_licenses":[]}]`))
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewEcosystemsService(EcosystemsServiceOptions{
+				BaseURL:        server.URL,
+				RequestTimeout: tt.requestTimeout,
+			})
+
+			purl, err := packageurl.FromString("pkg:npm/test@1.0.0")
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.contextTimeout)
+			defer cancel()
+
+			start := time.Now()
+			_, err = service.GetPackageInfo(ctx, purl)
+			if tt.wantErr {
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("GetPackageInfo() error = %v, want context.DeadlineExceeded", err)
+				}
+				if elapsed := time.Since(start); elapsed >= 200*time.Millisecond {
+					t.Errorf("GetPackageInfo() took %v, want the shorter timeout to apply", elapsed)
+				}
+			} else if err != nil {
+				t.Errorf("GetPackageInfo() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
 // TestEcosystemsService_UserAgent tests that the User-Agent header is set correctly.
 func TestEcosystemsService_UserAgent(t *testing.T) {
 	t.Parallel()
@@ -403,7 +739,7 @@ func TestEcosystemsService_UserAgent(t *testing.T) {
 		// Create mock server that checks User-Agent header.
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			userAgent := r.Header.Get("User-Agent")
-			expectedUserAgent := "purlinfo/" + version
+			expectedUserAgent := "purlinfo/" + version + " (+https://github.com/boringbin/purlinfo)"
 			if userAgent != expectedUserAgent {
 				t.Errorf("User-Agent = %q, want %q", userAgent, expectedUserAgent)
 			}
@@ -437,7 +773,7 @@ func TestEcosystemsService_UserAgent(t *testing.T) {
 		// Create mock server that checks User-Agent header.
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			userAgent := r.Header.Get("User-Agent")
-			expectedUserAgent := "purlinfo/" + version + " (mailto:" + email + ")"
+			expectedUserAgent := "purlinfo/" + version + " (+https://github.com/boringbin/purlinfo) (mailto:" + email + ")"
 			if userAgent != expectedUserAgent {
 				t.Errorf("User-Agent = %q, want %q", userAgent, expectedUserAgent)
 			}
@@ -463,33 +799,1040 @@ func TestEcosystemsService_UserAgent(t *testing.T) {
 			t.Errorf("GetPackageInfo() unexpected error = %v", err)
 		}
 	})
-}
 
-// contains checks if a string contains a substring.
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
-		(len(s) > 0 && len(substr) > 0 && containsHelper(s, substr)))
-}
+	t.Run("with custom user agent", func(t *testing.T) {
+		t.Parallel()
 
-// containsHelper is a helper function to check if a string contains a substring.
-func containsHelper(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
+		customUserAgent := "my-scanner/1.0"
+
+		// Create mock server that checks User-Agent header.
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if userAgent := r.Header.Get("User-Agent"); userAgent != customUserAgent {
+				t.Errorf("User-Agent = %q, want %q", userAgent, customUserAgent)
+			}
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`[{"name":"test","latest_release_number":"1.0.0","normalized_licenses":[]}]`))
+		}))
+		t.Cleanup(server.Close)
+
+		service := NewEcosystemsService(EcosystemsServiceOptions{
+			BaseURL:   server.URL,
+			UserAgent: customUserAgent,
+		})
+
+		purl, err := packageurl.FromString("pkg:npm/test@1.0.0")
+		if err != nil {
+			t.Fatalf("failed to parse purl: %v", err)
 		}
-	}
-	return false
-}
 
-// equalStringSlices compares string slices.
-func equalStringSlices(a, b []string) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	for i := range a {
-		if a[i] != b[i] {
-			return false
+		_, err = service.GetPackageInfo(context.Background(), purl)
+		if err != nil {
+			t.Errorf("GetPackageInfo() unexpected error = %v", err)
 		}
+	})
+}
+
+// TestEcosystemsService_FromHeader tests that the From header is set when an email is configured.
+func TestEcosystemsService_FromHeader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		email string
+	}{
+		{
+			name:  "without email",
+			email: "",
+		},
+		{
+			name:  "with email",
+			email: "test@example.com",
+		},
 	}
-	return true
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Create mock server that checks the From header.
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if from := r.Header.Get("From"); from != tt.email {
+					t.Errorf("From = %q, want %q", from, tt.email)
+				}
+
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`[{"name":"test","latest_release_number":"1.0.0","normalized_licenses":[]}]`))
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewEcosystemsService(EcosystemsServiceOptions{
+				BaseURL: server.URL,
+				Email:   tt.email,
+			})
+
+			purl, err := packageurl.FromString("pkg:npm/test@1.0.0")
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			_, err = service.GetPackageInfo(context.Background(), purl)
+			if err != nil {
+				t.Errorf("GetPackageInfo() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+// TestEcosystemsService_ListVersions tests the ListVersions method.
+func TestEcosystemsService_ListVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		lookupResult string
+		want         []string
+		wantErr      error
+	}{
+		{
+			name:         "sorted versions",
+			lookupResult: `[{"name":"lodash","versions_url":"%s/versions"}]`,
+			want:         []string{"4.9.0", "4.17.20", "4.17.21"},
+		},
+		{
+			name:         "missing versions URL",
+			lookupResult: `[{"name":"lodash"}]`,
+			wantErr:      ErrInvalidResponse,
+		},
+		{
+			name:         "package not found",
+			lookupResult: `[]`,
+			wantErr:      ErrPackageNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := http.NewServeMux()
+			server := httptest.NewServer(mux)
+			t.Cleanup(server.Close)
+
+			mux.HandleFunc(ecosystemsAPIPath, func(w http.ResponseWriter, _ *http.Request) {
+				if strings.Contains(tt.lookupResult, "%s") {
+					fmt.Fprintf(w, tt.lookupResult, server.URL)
+					return
+				}
+				_, _ = w.Write([]byte(tt.lookupResult))
+			})
+			mux.HandleFunc("/versions", func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`[{"number":"4.17.21"},{"number":"4.9.0"},{"number":"4.17.20"}]`))
+			})
+
+			service := NewEcosystemsService(EcosystemsServiceOptions{
+				BaseURL: server.URL,
+			})
+
+			purl, err := packageurl.FromString("pkg:npm/lodash")
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.ListVersions(context.Background(), purl)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ListVersions() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ListVersions() unexpected error = %v", err)
+			}
+
+			if !equalStringSlices(got, tt.want) {
+				t.Errorf("ListVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestEcosystemsService_ListMatches tests the ListMatches method.
+func TestEcosystemsService_ListMatches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		lookupResult string
+		wantNames    []string
+		wantErr      error
+	}{
+		{
+			name:         "single match",
+			lookupResult: `[{"name":"lodash","latest_release_number":"4.17.21"}]`,
+			wantNames:    []string{"lodash"},
+		},
+		{
+			name: "matches in several registries",
+			lookupResult: `[{"name":"lodash","latest_release_number":"4.17.21"},` +
+				`{"name":"lodash-mirror","latest_release_number":"4.17.20"}]`,
+			wantNames: []string{"lodash", "lodash-mirror"},
+		},
+		{
+			name:         "invalid match",
+			lookupResult: `[{"name":"lodash"},{"latest_release_number":"4.17.20"}]`,
+			wantErr:      ErrInvalidResponse,
+		},
+		{
+			name:         "package not found",
+			lookupResult: `[]`,
+			wantErr:      ErrPackageNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(tt.lookupResult))
+			}))
+			t.Cleanup(server.Close)
+
+			// The registry endpoint must not be used, even if preferred
+			service := NewEcosystemsService(EcosystemsServiceOptions{
+				BaseURL:                server.URL,
+				PreferRegistryEndpoint: true,
+			})
+
+			purl, err := packageurl.FromString("pkg:npm/lodash@4.17.21")
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.ListMatches(context.Background(), purl)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ListMatches() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ListMatches() unexpected error = %v", err)
+			}
+
+			names := make([]string, 0, len(got))
+			for _, info := range got {
+				names = append(names, info.Name)
+				if info.Ecosystem != "npm" {
+					t.Errorf("ListMatches() Ecosystem = %q, want %q", info.Ecosystem, "npm")
+				}
+			}
+			if !equalStringSlices(names, tt.wantNames) {
+				t.Errorf("ListMatches() names = %v, want %v", names, tt.wantNames)
+			}
+		})
+	}
+}
+
+// TestEcosystemsService_SearchPackages tests the SearchPackages method.
+func TestEcosystemsService_SearchPackages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		ecosystem string
+		limit     int
+		wantQuery string
+		wantNames []string
+	}{
+		{
+			name:      "with ecosystem and limit",
+			ecosystem: "npm",
+			limit:     2,
+			wantQuery: "ecosystem=npm&per_page=2&q=lodash",
+			wantNames: []string{"lodash", "lodash.merge"},
+		},
+		{
+			name:      "without ecosystem and limit",
+			wantQuery: "q=lodash",
+			wantNames: []string{"lodash", "lodash.merge", "lodash-es"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != ecosystemsSearchAPIPath {
+					t.Errorf("request path = %q, want %q", r.URL.Path, ecosystemsSearchAPIPath)
+				}
+				if r.URL.RawQuery != tt.wantQuery {
+					t.Errorf("request query = %q, want %q", r.URL.RawQuery, tt.wantQuery)
+				}
+
+				// Return more results than requested to check the limit is enforced.
+				_, _ = w.Write([]byte(`[
+					{"name":"lodash","ecosystem":"npm","latest_release_number":"4.17.21","normalized_licenses":["MIT"]},
+					{"name":"lodash.merge","ecosystem":"npm","latest_release_number":"4.6.2","normalized_licenses":["MIT"]},
+					{"name":"lodash-es","ecosystem":"npm","latest_release_number":"4.17.21","normalized_licenses":["MIT"]}
+				]`))
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewEcosystemsService(EcosystemsServiceOptions{
+				BaseURL: server.URL,
+			})
+
+			got, err := service.SearchPackages(context.Background(), "lodash", tt.ecosystem, tt.limit)
+			if err != nil {
+				t.Fatalf("SearchPackages() unexpected error = %v", err)
+			}
+
+			gotNames := make([]string, 0, len(got))
+			for _, info := range got {
+				gotNames = append(gotNames, info.Name)
+				if info.Ecosystem != "npm" {
+					t.Errorf("SearchPackages() Ecosystem = %q, want %q", info.Ecosystem, "npm")
+				}
+			}
+			if !equalStringSlices(gotNames, tt.wantNames) {
+				t.Errorf("SearchPackages() names = %v, want %v", gotNames, tt.wantNames)
+			}
+		})
+	}
+}
+
+// TestEcosystemsService_PreferRegistryEndpoint tests the registry-specific lookup strategy.
+func TestEcosystemsService_PreferRegistryEndpoint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		purl     string
+		prefer   bool
+		wantPath string
+	}{
+		{
+			name:     "maven purl",
+			purl:     "pkg:maven/org.apache.commons/commons-lang3@3.14.0",
+			prefer:   true,
+			wantPath: "/api/v1/registries/repo1.maven.org/packages/org.apache.commons:commons-lang3",
+		},
+		{
+			name:     "scoped npm purl",
+			purl:     "pkg:npm/%40angular/core@17.0.0",
+			prefer:   true,
+			wantPath: "/api/v1/registries/npmjs.org/packages/@angular%2Fcore",
+		},
+		{
+			name:     "purl without namespace",
+			purl:     "pkg:npm/lodash@4.17.21",
+			prefer:   true,
+			wantPath: ecosystemsAPIPath,
+		},
+		{
+			name:     "purl type without registry",
+			purl:     "pkg:github/package-url/purl-spec",
+			prefer:   true,
+			wantPath: ecosystemsAPIPath,
+		},
+		{
+			name:     "not found in registry",
+			purl:     "pkg:golang/github.com/example/missing@v1.0.0",
+			prefer:   true,
+			wantPath: ecosystemsAPIPath,
+		},
+		{
+			name:     "not preferred",
+			purl:     "pkg:maven/org.apache.commons/commons-lang3@3.14.0",
+			prefer:   false,
+			wantPath: ecosystemsAPIPath,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotPath atomic.Value
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/missing") {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				gotPath.Store(r.URL.EscapedPath())
+
+				if r.URL.Path == ecosystemsAPIPath {
+					_, _ = w.Write([]byte(`[{"name":"from-lookup","normalized_licenses":[]}]`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"name":"from-registry","normalized_licenses":[]}`))
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewEcosystemsService(EcosystemsServiceOptions{
+				BaseURL:                server.URL,
+				PreferRegistryEndpoint: tt.prefer,
+			})
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.GetPackageInfo(context.Background(), purl)
+			if err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+
+			if path := gotPath.Load(); path != tt.wantPath {
+				t.Errorf("request path = %v, want %q", path, tt.wantPath)
+			}
+			wantName := "from-registry"
+			if tt.wantPath == ecosystemsAPIPath {
+				wantName = "from-lookup"
+			}
+			if got.Name != wantName {
+				t.Errorf("GetPackageInfo() Name = %q, want %q", got.Name, wantName)
+			}
+		})
+	}
+}
+
+// TestEcosystemsService_EcosystemBaseURLOverrides tests that purls of overridden types are looked up
+// on their own base URL.
+func TestEcosystemsService_EcosystemBaseURLOverrides(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                   string
+		purl                   string
+		preferRegistryEndpoint bool
+		wantOverride           bool
+	}{
+		{name: "overridden type", purl: "pkg:maven/org.apache.commons/commons-lang3@3.12.0", wantOverride: true},
+		{
+			name:                   "overridden type with registry endpoint",
+			purl:                   "pkg:maven/org.apache.commons/commons-lang3@3.12.0",
+			preferRegistryEndpoint: true,
+			wantOverride:           true,
+		},
+		{name: "overridden type in another case", purl: "pkg:Maven/junit/junit@4.13.2", wantOverride: true},
+		{name: "other type", purl: "pkg:npm/lodash@4.17.21", wantOverride: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			newServer := func(requests *atomic.Int32) *httptest.Server {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					requests.Add(1)
+					if strings.HasPrefix(r.URL.Path, ecosystemsRegistriesAPIPath) {
+						_, _ = w.Write([]byte(`{"name":"test","latest_release_number":"1.0.0"}`))
+						return
+					}
+					_, _ = w.Write([]byte(`[{"name":"test","latest_release_number":"1.0.0"}]`))
+				}))
+				t.Cleanup(server.Close)
+				return server
+			}
+			var defaultRequests, overrideRequests atomic.Int32
+			defaultServer, overrideServer := newServer(&defaultRequests), newServer(&overrideRequests)
+
+			service := NewEcosystemsService(EcosystemsServiceOptions{
+				BaseURL:                   defaultServer.URL,
+				EcosystemBaseURLOverrides: map[string]string{"maven": overrideServer.URL},
+				PreferRegistryEndpoint:    tt.preferRegistryEndpoint,
+			})
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+			if _, err = service.GetPackageInfo(context.Background(), purl); err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+
+			gotOverride := overrideRequests.Load() > 0 && defaultRequests.Load() == 0
+			if gotOverride != tt.wantOverride {
+				t.Errorf("requests to the default base URL = %d, to the override = %d, want override = %v",
+					defaultRequests.Load(), overrideRequests.Load(), tt.wantOverride)
+			}
+		})
+	}
+}
+
+// TestEcosystemsService_Pagination tests that the pages linked by the Link header are followed.
+func TestEcosystemsService_Pagination(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		limit     int
+		wantNames []string
+		wantPages int32
+	}{
+		{
+			name:      "all pages",
+			limit:     0,
+			wantNames: []string{"a", "b", "c", "d", "e"},
+			wantPages: 3,
+		},
+		{
+			name:      "stops at the limit",
+			limit:     3,
+			wantNames: []string{"a", "b", "c"},
+			wantPages: 2,
+		},
+		{
+			name:      "limit within the first page",
+			limit:     1,
+			wantNames: []string{"a"},
+			wantPages: 1,
+		},
+	}
+
+	// Each page links to the next one; the last page has no next link.
+	pages := map[string]struct {
+		body string
+		link string
+	}{
+		"": {body: `[{"name":"a"},{"name":"b"}]`, link: `<?q=test&page=2>; rel="next"`},
+		"2": {
+			body: `[{"name":"c"},{"name":"d"}]`,
+			link: `</api/v1/packages/search?q=test&page=1>; rel="prev", </api/v1/packages/search?q=test&page=3>; rel="next"`,
+		},
+		"3": {body: `[{"name":"e"}]`, link: `</api/v1/packages/search?q=test&page=2>; rel="prev"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				page, ok := pages[r.URL.Query().Get("page")]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Link", page.link)
+				_, _ = w.Write([]byte(page.body))
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewEcosystemsService(EcosystemsServiceOptions{
+				BaseURL: server.URL,
+			})
+
+			got, err := service.SearchPackages(context.Background(), "test", "", tt.limit)
+			if err != nil {
+				t.Fatalf("SearchPackages() unexpected error = %v", err)
+			}
+
+			gotNames := make([]string, 0, len(got))
+			for _, info := range got {
+				gotNames = append(gotNames, info.Name)
+			}
+			if !equalStringSlices(gotNames, tt.wantNames) {
+				t.Errorf("SearchPackages() names = %v, want %v", gotNames, tt.wantNames)
+			}
+			if gotPages := requests.Load(); gotPages != tt.wantPages {
+				t.Errorf("server received %d requests, want %d", gotPages, tt.wantPages)
+			}
+		})
+	}
+}
+
+// TestEcosystemsService_ConnectionReuse tests that consecutive lookups reuse the HTTP connection.
+func TestEcosystemsService_ConnectionReuse(t *testing.T) {
+	t.Parallel()
+
+	const lookups = 10
+
+	var gzipped bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipped)
+	_, _ = gzipWriter.Write([]byte(`[{"name": "lodash", "latest_release_number": "4.17.21"}]` + "\n\n"))
+	_ = gzipWriter.Close()
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		wantErr bool
+	}{
+		{
+			name: "plain response",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				// Trailing whitespace after the JSON value must not prevent reuse
+				_, _ = w.Write([]byte(`[{"name": "lodash", "latest_release_number": "4.17.21"}]` + "\n\n"))
+			},
+		},
+		{
+			name: "gzip response",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Encoding", "gzip")
+				_, _ = w.Write(gzipped.Bytes())
+			},
+		},
+		{
+			name: "error response",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error": "not found"}`))
+			},
+			wantErr: true,
+		},
+		{
+			name: "large error page",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte("<html>" + strings.Repeat("unavailable ", 1<<13) + "</html>"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var mu sync.Mutex
+			newConnections := 0
+			server := httptest.NewUnstartedServer(tt.handler)
+			server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					mu.Lock()
+					newConnections++
+					mu.Unlock()
+				}
+			}
+			server.Start()
+			t.Cleanup(server.Close)
+
+			// Use the client purlinfo itself creates
+			service := NewEcosystemsService(EcosystemsServiceOptions{
+				BaseURL: server.URL,
+				Client:  newHTTPClient(5*time.Second, "", "", nil),
+			})
+
+			purl, err := packageurl.FromString("pkg:npm/lodash@4.17.21")
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			for range lookups {
+				_, err = service.GetPackageInfo(context.Background(), purl)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("GetPackageInfo() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+
+			// A connection is returned to the pool asynchronously, so an occasional extra
+			// connection is fine; one connection per lookup means keep-alive is not working.
+			mu.Lock()
+			defer mu.Unlock()
+			if newConnections >= lookups {
+				t.Errorf("%d lookups opened %d connections, want fewer", lookups, newConnections)
+			}
+		})
+	}
+}
+
+// TestEcosystemsService_Gzip tests that gzip responses are requested and decompressed.
+func TestEcosystemsService_Gzip(t *testing.T) {
+	t.Parallel()
+
+	const body = `[{"name":"test","latest_release_number":"1.0.0","normalized_licenses":["MIT"]}]`
+
+	tests := []struct {
+		name     string
+		encoding string
+		body     func(t *testing.T) []byte
+		wantErr  error
+	}{
+		{
+			name:     "gzip response",
+			encoding: "gzip",
+			body: func(t *testing.T) []byte {
+				t.Helper()
+
+				var buf bytes.Buffer
+				gzipWriter := gzip.NewWriter(&buf)
+				if _, err := gzipWriter.Write([]byte(body)); err != nil {
+					t.Fatalf("failed to compress body: %v", err)
+				}
+				if err := gzipWriter.Close(); err != nil {
+					t.Fatalf("failed to compress body: %v", err)
+				}
+				return buf.Bytes()
+			},
+		},
+		{
+			name:     "uncompressed response",
+			encoding: "",
+			body:     func(*testing.T) []byte { return []byte(body) },
+		},
+		{
+			name:     "corrupt gzip response",
+			encoding: "gzip",
+			body:     func(*testing.T) []byte { return []byte(body) },
+			wantErr:  ErrInvalidResponse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			responseBody := tt.body(t)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Accept-Encoding"); got != "gzip" {
+					t.Errorf("Accept-Encoding = %q, want %q", got, "gzip")
+				}
+				if tt.encoding != "" {
+					w.Header().Set("Content-Encoding", tt.encoding)
+				}
+				_, _ = w.Write(responseBody)
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewEcosystemsService(EcosystemsServiceOptions{
+				BaseURL: server.URL,
+			})
+
+			purl, err := packageurl.FromString("pkg:npm/test@1.0.0")
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.GetPackageInfo(context.Background(), purl)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetPackageInfo() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+			if got.Name != "test" {
+				t.Errorf("GetPackageInfo() Name = %q, want %q", got.Name, "test")
+			}
+		})
+	}
+}
+
+// TestEcosystemsService_RetryAfter tests that rate limited requests are retried after the Retry-After delay.
+func TestEcosystemsService_RetryAfter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		retryAfter   string
+		timeout      time.Duration
+		wantRequests int32
+		wantErr      bool
+	}{
+		{
+			name:         "retried after delay",
+			retryAfter:   "0",
+			timeout:      5 * time.Second,
+			wantRequests: 2,
+		},
+		{
+			name:         "no Retry-After header",
+			retryAfter:   "",
+			timeout:      5 * time.Second,
+			wantRequests: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "delay exceeds timeout",
+			retryAfter:   "3600",
+			timeout:      5 * time.Second,
+			wantRequests: 1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				// Rate limit the first request only.
+				if requests.Add(1) == 1 {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+
+				_, _ = w.Write([]byte(`[{"name":"test","latest_release_number":"1.0.0","normalized_licenses":[]}]`))
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewEcosystemsService(EcosystemsServiceOptions{
+				BaseURL: server.URL,
+			})
+
+			purl, err := packageurl.FromString("pkg:npm/test@1.0.0")
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+
+			_, err = service.GetPackageInfo(ctx, purl)
+			if tt.wantErr {
+				if err == nil || !contains(err.Error(), "rate limited") {
+					t.Errorf("GetPackageInfo() error = %v, want rate limited error", err)
+				}
+			} else if err != nil {
+				t.Errorf("GetPackageInfo() unexpected error = %v", err)
+			}
+
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("server received %d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+// TestEcosystemsService_APIVersion tests the warning about an unsupported API version.
+func TestEcosystemsService_APIVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		apiVersion    string
+		minAPIVersion string
+		wantWarning   string
+	}{
+		{
+			name:          "below minimum",
+			apiVersion:    "1.0",
+			minAPIVersion: "1.1",
+			wantWarning:   "Warning: API version 1.0 is below minimum supported 1.1. Some fields may be missing.\n",
+		},
+		{
+			name:          "equal to minimum",
+			apiVersion:    "1.1",
+			minAPIVersion: "1.1",
+		},
+		{
+			name:          "above minimum",
+			apiVersion:    "2",
+			minAPIVersion: "1.1",
+		},
+		{
+			name:       "above default minimum",
+			apiVersion: "v1.2",
+		},
+		{
+			name:       "below default minimum",
+			apiVersion: "0.9",
+			wantWarning: "Warning: API version 0.9 is below minimum supported " + ecosystemsMinAPIVersion +
+				". Some fields may be missing.\n",
+		},
+		{
+			name:          "no header",
+			minAPIVersion: "1.1",
+		},
+		{
+			name:          "unparsable version",
+			apiVersion:    "2024-01-15",
+			minAPIVersion: "1.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if tt.apiVersion != "" {
+					w.Header().Set("API-Version", tt.apiVersion)
+				}
+				_, _ = w.Write([]byte(`[{"name":"test","latest_release_number":"1.0.0","normalized_licenses":[]}]`))
+			}))
+			t.Cleanup(server.Close)
+
+			var warnings bytes.Buffer
+			service := NewEcosystemsService(EcosystemsServiceOptions{
+				BaseURL:       server.URL,
+				MinAPIVersion: tt.minAPIVersion,
+				Warnings:      &warnings,
+			})
+
+			purl, err := packageurl.FromString("pkg:npm/test@1.0.0")
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			// The warning is printed once, however many requests are made.
+			for range 2 {
+				if _, err = service.GetPackageInfo(context.Background(), purl); err != nil {
+					t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+				}
+			}
+
+			if got := warnings.String(); got != tt.wantWarning {
+				t.Errorf("warnings = %q, want %q", got, tt.wantWarning)
+			}
+		})
+	}
+}
+
+// TestCompareAPIVersions tests the compareAPIVersions function.
+func TestCompareAPIVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b   string
+		want   int
+		wantOK bool
+	}{
+		{a: "1.0", b: "1.1", want: -1, wantOK: true},
+		{a: "1.1", b: "1.0", want: 1, wantOK: true},
+		{a: "1", b: "1.0", want: 0, wantOK: true},
+		{a: "v2", b: "1.10", want: 1, wantOK: true},
+		{a: "1.9", b: "1.10", want: -1, wantOK: true},
+		{a: "1.x", b: "1.0", wantOK: false},
+		{a: "1.0", b: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := compareAPIVersions(tt.a, tt.b)
+			if ok != tt.wantOK || (ok && got != tt.want) {
+				t.Errorf("compareAPIVersions(%q, %q) = %d, %v, want %d, %v", tt.a, tt.b, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// benchmarkLookupResponse is a canned Ecosystems lookup response used by the benchmarks.
+const benchmarkLookupResponse = `[{
+	"name": "lodash",
+	"ecosystem": "npm",
+	"latest_release_number": "4.17.21",
+	"normalized_licenses": ["MIT"],
+	"homepage": "https://lodash.com/",
+	"repository_url": "https://github.com/lodash/lodash",
+	"description": "Lodash modular utilities.",
+	"documentation_url": "https://lodash.com/docs",
+	"keywords": ["modules", "stdlib", "util"],
+	"versions_count": 114,
+	"dependent_packages_count": 171423,
+	"downloads": 258326720
+}]`
+
+// BenchmarkGetPackageInfo measures GetPackageInfo against a mock server.
+//
+// The sub-benchmarks separate the cost of the HTTP round-trip from the cost of decoding the response.
+// Run with `go test -run='^$' -bench=. -benchmem`.
+func BenchmarkGetPackageInfo(b *testing.B) {
+	var gzipped bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipped)
+	if _, err := gzipWriter.Write([]byte(benchmarkLookupResponse)); err != nil {
+		b.Fatalf("failed to gzip response: %v", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		b.Fatalf("failed to gzip response: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(benchmarkLookupResponse))
+	}))
+	b.Cleanup(server.Close)
+	gzipServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(gzipped.Bytes())
+	}))
+	b.Cleanup(gzipServer.Close)
+
+	purl, err := packageurl.FromString("pkg:npm/lodash@4.17.21")
+	if err != nil {
+		b.Fatalf("failed to parse purl: %v", err)
+	}
+
+	benchmarks := []struct {
+		name    string
+		baseURL string
+	}{
+		{name: "http", baseURL: server.URL},
+		{name: "http gzip", baseURL: gzipServer.URL},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			service := NewEcosystemsService(EcosystemsServiceOptions{BaseURL: bm.baseURL})
+			b.ReportAllocs()
+
+			for b.Loop() {
+				if _, lookupErr := service.GetPackageInfo(context.Background(), purl); lookupErr != nil {
+					b.Fatalf("GetPackageInfo() error = %v", lookupErr)
+				}
+			}
+		})
+	}
+
+	b.Run("decode only", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for b.Loop() {
+			var results []ecosystemsPackagesLookupResponse
+			if decodeErr := json.Unmarshal([]byte(benchmarkLookupResponse), &results); decodeErr != nil {
+				b.Fatalf("failed to decode response: %v", decodeErr)
+			}
+			_ = results[0].packageInfo(purl.Type)
+		}
+	})
+}
+
+// contains checks if a string contains a substring.
+func contains(s, substr string) bool {
+	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
+		(len(s) > 0 && len(substr) > 0 && containsHelper(s, substr)))
+}
+
+// containsHelper is a helper function to check if a string contains a substring.
+func containsHelper(s, substr string) bool {
+	for i := 0; i <= len(s)-len(substr); i++ {
+		if s[i:i+len(substr)] == substr {
+			return true
+		}
+	}
+	return false
+}
+
+// equalStringSlices compares string slices.
+func equalStringSlices(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// equalIntPointers compares int pointers by value; two nil pointers are equal.
+func equalIntPointers(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
 }
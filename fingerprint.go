@@ -0,0 +1,83 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// fingerprintPrefix is the prefix of the fingerprints, naming the hash algorithm.
+const fingerprintPrefix = "SHA256:"
+
+// fingerprintedInfo holds the fields of a PackageInfo that the fingerprint covers.
+//
+// Only stable metadata is fingerprinted: counts (downloads, forks, watchers, contributors), the last
+// commit date and the Scorecard score change without the package being modified, so they are left out.
+// The fields keep the JSON names of PackageInfo.
+type fingerprintedInfo struct {
+	Name                  string     `json:"name"`
+	Version               string     `json:"version"`
+	Licenses              []string   `json:"licenses"`
+	LicenseSPDXExpression string     `json:"license_spdx_expression,omitempty"`
+	Homepage              string     `json:"homepage,omitempty"`
+	RepositoryURL         string     `json:"repository_url,omitempty"`
+	Description           string     `json:"description,omitempty"`
+	Ecosystem             string     `json:"ecosystem"`
+	DocumentationURL      string     `json:"documentation_url,omitempty"`
+	BugTrackerURL         string     `json:"bug_tracker_url,omitempty"`
+	ChangelogURL          string     `json:"changelog_url,omitempty"`
+	SecurityPolicyURL     string     `json:"security_policy_url,omitempty"`
+	RegistryURL           string     `json:"registry_url,omitempty"`
+	OriginalPURL          string     `json:"original_purl"`
+	ResolvedPURL          string     `json:"resolved_purl,omitempty"`
+	DependencyCount       *int       `json:"dependency_count,omitempty"`
+	LastPublishedDate     *time.Time `json:"last_published_date,omitempty"`
+}
+
+// packageInfoFingerprint returns a SHA-256 fingerprint of the package info, in the form "SHA256:<hex>".
+//
+// The fingerprint is the hash of the compact JSON serialization of the stable fields of info
+// (see fingerprintedInfo). encoding/json serializes struct fields in declaration order, so the
+// fingerprint only changes when the package metadata does, for example when the licenses of a package
+// are modified upstream.
+func packageInfoFingerprint(info PackageInfo) (string, error) {
+	data, err := json.Marshal(fingerprintedInfo{
+		Name:                  info.Name,
+		Version:               info.Version,
+		Licenses:              info.Licenses,
+		LicenseSPDXExpression: info.LicenseSPDXExpression,
+		Homepage:              info.Homepage,
+		RepositoryURL:         info.RepositoryURL,
+		Description:           info.Description,
+		Ecosystem:             info.Ecosystem,
+		DocumentationURL:      info.DocumentationURL,
+		BugTrackerURL:         info.BugTrackerURL,
+		ChangelogURL:          info.ChangelogURL,
+		SecurityPolicyURL:     info.SecurityPolicyURL,
+		RegistryURL:           info.RegistryURL,
+		OriginalPURL:          info.OriginalPURL,
+		ResolvedPURL:          info.ResolvedPURL,
+		DependencyCount:       info.DependencyCount,
+		LastPublishedDate:     info.LastPublishedDate,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return fingerprintPrefix + hex.EncodeToString(sum[:]), nil
+}
+
+// printFingerprintOutput prints the fingerprint of the package info to w.
+func printFingerprintOutput(w io.Writer, info PackageInfo) error {
+	fingerprint, err := packageInfoFingerprint(info)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, fingerprint)
+	return nil
+}
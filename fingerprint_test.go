@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestPackageInfoFingerprint tests the packageInfoFingerprint function.
+func TestPackageInfoFingerprint(t *testing.T) {
+	t.Parallel()
+
+	lodash := PackageInfo{
+		Name:      "lodash",
+		Version:   "4.17.21",
+		Licenses:  []string{"MIT"},
+		Ecosystem: "npm",
+	}
+	relicensed := lodash
+	relicensed.Licenses = []string{"Apache-2.0"}
+	downloads, forks := 1000, 7
+	downloaded := lodash
+	downloaded.DownloadCount = &downloads
+	forked := lodash
+	forked.ForksCount = &forks
+
+	tests := []struct {
+		name      string
+		info      PackageInfo
+		other     PackageInfo
+		wantEqual bool
+	}{
+		{name: "same package info", info: lodash, other: lodash, wantEqual: true},
+		{name: "modified licenses", info: lodash, other: relicensed, wantEqual: false},
+		{name: "modified version", info: lodash, other: PackageInfo{
+			Name: "lodash", Version: "4.17.20", Licenses: []string{"MIT"}, Ecosystem: "npm",
+		}, wantEqual: false},
+		{name: "modified download count", info: lodash, other: downloaded, wantEqual: true},
+		{name: "modified forks count", info: lodash, other: forked, wantEqual: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := packageInfoFingerprint(tt.info)
+			if err != nil {
+				t.Fatalf("packageInfoFingerprint() unexpected error = %v", err)
+			}
+			other, err := packageInfoFingerprint(tt.other)
+			if err != nil {
+				t.Fatalf("packageInfoFingerprint() unexpected error = %v", err)
+			}
+
+			hexDigest, ok := strings.CutPrefix(got, fingerprintPrefix)
+			if !ok || len(hexDigest) != 64 {
+				t.Errorf("packageInfoFingerprint() = %q, want %s followed by 64 hex digits", got, fingerprintPrefix)
+			}
+			if (got == other) != tt.wantEqual {
+				t.Errorf("fingerprints %q and %q: equal = %v, want %v", got, other, got == other, tt.wantEqual)
+			}
+		})
+	}
+}
+
+// TestPackageInfoFingerprint_Known tests that the fingerprint is the SHA-256 of the compact JSON.
+func TestPackageInfoFingerprint_Known(t *testing.T) {
+	t.Parallel()
+
+	info := PackageInfo{Name: "lodash", Version: "4.17.21", Licenses: []string{"MIT"}, Ecosystem: "npm"}
+	// echo -n '{"name":"lodash","version":"4.17.21","licenses":["MIT"],"ecosystem":"npm","original_purl":""}' |
+	// sha256sum
+	want := "SHA256:e69b24f85de855645d1da1c1df8dbd4f5bcef77fd8665cf754788097a431b4e0"
+
+	got, err := packageInfoFingerprint(info)
+	if err != nil {
+		t.Fatalf("packageInfoFingerprint() unexpected error = %v", err)
+	}
+	if got != want {
+		t.Errorf("packageInfoFingerprint() = %q, want %q", got, want)
+	}
+}
+
+// TestPrintPackageList_Fingerprint tests that the fingerprint output has one fingerprint per package.
+func TestPrintPackageList_Fingerprint(t *testing.T) {
+	t.Parallel()
+
+	results := []PackageInfo{
+		{Name: "lodash", Ecosystem: "npm"},
+		{Name: "lodash", Ecosystem: "bower"},
+	}
+
+	var buf bytes.Buffer
+	if err := printPackageList(&buf, results, formatFingerprint); err != nil {
+		t.Fatalf("printPackageList() unexpected error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(results) {
+		t.Fatalf("printPackageList() printed %d lines, want %d:\n%s", len(lines), len(results), buf.String())
+	}
+	for i, line := range lines {
+		want, err := packageInfoFingerprint(results[i])
+		if err != nil {
+			t.Fatalf("packageInfoFingerprint() unexpected error = %v", err)
+		}
+		if line != want {
+			t.Errorf("printPackageList() line %d = %q, want %q", i, line, want)
+		}
+	}
+}
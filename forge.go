@@ -0,0 +1,57 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+)
+
+// forgeRepository returns the path of a repository hosted on GitHub or GitLab (e.g., github.com/lodash/lodash),
+// or false if the URL is not the URL of a repository on one of these forges.
+//
+// Repository URLs as found in package metadata are accepted, such as git+https://github.com/o/r.git.
+// The path is also the project name used by the OpenSSF Scorecard API.
+func forgeRepository(repositoryURL string) (string, bool) {
+	u, err := url.Parse(strings.TrimPrefix(strings.TrimSpace(repositoryURL), "git+"))
+	if err != nil {
+		return "", false
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if host != "github.com" && host != "gitlab.com" {
+		return "", false
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 2 || segments[0] == "" || segments[1] == "" {
+		return "", false
+	}
+	owner, repo := segments[0], strings.TrimSuffix(segments[1], ".git")
+
+	return host + "/" + owner + "/" + repo, true
+}
+
+// releasesPageURL returns the URL of the releases page of a repository hosted on GitHub or GitLab,
+// or an empty string if the repository is not on one of these forges.
+func releasesPageURL(repositoryURL string) string {
+	repository, ok := forgeRepository(repositoryURL)
+	if !ok {
+		return ""
+	}
+
+	if strings.HasPrefix(repository, "gitlab.com/") {
+		return "https://" + repository + "/-/releases"
+	}
+	return "https://" + repository + "/releases"
+}
+
+// securityPolicyURL returns the URL of the security policy page of a repository hosted on GitHub,
+// or an empty string if the repository is not on GitHub.
+//
+// GitHub serves the page for every repository, showing the SECURITY.md file if there is one.
+func securityPolicyURL(repositoryURL string) string {
+	repository, ok := forgeRepository(repositoryURL)
+	if !ok || !strings.HasPrefix(repository, "github.com/") {
+		return ""
+	}
+	return "https://" + repository + "/security/policy"
+}
@@ -0,0 +1,87 @@
+package main
+
+import "testing"
+
+// TestForgeRepository tests the forgeRepository function.
+func TestForgeRepository(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		repositoryURL string
+		want          string
+		wantOK        bool
+	}{
+		{repositoryURL: "https://github.com/lodash/lodash", want: "github.com/lodash/lodash", wantOK: true},
+		{repositoryURL: "https://github.com/lodash/lodash.git", want: "github.com/lodash/lodash", wantOK: true},
+		{repositoryURL: "git+https://github.com/lodash/lodash.git", want: "github.com/lodash/lodash", wantOK: true},
+		{repositoryURL: "https://www.github.com/psf/requests/", want: "github.com/psf/requests", wantOK: true},
+		{repositoryURL: "https://GitHub.com/psf/requests", want: "github.com/psf/requests", wantOK: true},
+		{repositoryURL: "https://github.com/psf/requests/tree/main/src", want: "github.com/psf/requests", wantOK: true},
+		{repositoryURL: "https://gitlab.com/gitlab-org/gitlab", want: "gitlab.com/gitlab-org/gitlab", wantOK: true},
+		{repositoryURL: "https://bitbucket.org/owner/repo", wantOK: false},
+		{repositoryURL: "https://github.com/lodash", wantOK: false},
+		{repositoryURL: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.repositoryURL, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := forgeRepository(tt.repositoryURL)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("forgeRepository(%q) = %q, %v, want %q, %v", tt.repositoryURL, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// TestReleasesPageURL tests the releasesPageURL function.
+func TestReleasesPageURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		repositoryURL string
+		want          string
+	}{
+		{repositoryURL: "git+https://github.com/lodash/lodash.git", want: "https://github.com/lodash/lodash/releases"},
+		{repositoryURL: "https://github.com/rails/rails/tree/v7.1.3", want: "https://github.com/rails/rails/releases"},
+		{repositoryURL: "https://gitlab.com/gitlab-org/gitlab", want: "https://gitlab.com/gitlab-org/gitlab/-/releases"},
+		{repositoryURL: "https://bitbucket.org/owner/repo", want: ""},
+		{repositoryURL: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.repositoryURL, func(t *testing.T) {
+			t.Parallel()
+
+			if got := releasesPageURL(tt.repositoryURL); got != tt.want {
+				t.Errorf("releasesPageURL(%q) = %q, want %q", tt.repositoryURL, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSecurityPolicyURL tests the securityPolicyURL function.
+func TestSecurityPolicyURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		repositoryURL string
+		want          string
+	}{
+		{repositoryURL: "git+https://github.com/lodash/lodash.git", want: "https://github.com/lodash/lodash/security/policy"},
+		{repositoryURL: "https://github.com/rails/rails/tree/v7.1.3", want: "https://github.com/rails/rails/security/policy"},
+		{repositoryURL: "https://gitlab.com/gitlab-org/gitlab", want: ""},
+		{repositoryURL: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.repositoryURL, func(t *testing.T) {
+			t.Parallel()
+
+			if got := securityPolicyURL(tt.repositoryURL); got != tt.want {
+				t.Errorf("securityPolicyURL(%q) = %q, want %q", tt.repositoryURL, got, tt.want)
+			}
+		})
+	}
+}
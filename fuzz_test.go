@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// FuzzGetPackageInfo feeds arbitrary strings to packageurl.FromString and looks up the valid purls.
+//
+// It checks that purl parsing, the URL construction of EcosystemsService and the output of PackageInfo
+// do not panic, and that the purl reaches the lookup endpoint unchanged. Run it with:
+//
+//	go test -run='^$' -fuzz=FuzzGetPackageInfo
+func FuzzGetPackageInfo(f *testing.F) {
+	seeds := []string{
+		"pkg:npm/lodash@4.17.21",
+		"pkg:npm/%40types/node@18.0.0",
+		"pkg:pypi/requests@2.28.0",
+		"pkg:maven/org.apache.commons/commons-lang3@3.12.0",
+		"pkg:golang/github.com/BurntSushi/toml@v1.3.2",
+		"pkg:docker/library/nginx@latest?arch=amd64",
+		"pkg:gem/rails@7.0.0#subpath",
+		"pkg:generic/name%20with%20spaces@1.0?download_url=https://example.com/a?b=c&d",
+		"pkg:unknown/ns/na&me@v?q=%26#frag",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	// The server checks that the purl query parameter survived URL construction and returns a canned package.
+	var lastPURL string
+	mux := http.NewServeMux()
+	mux.HandleFunc(ecosystemsAPIPath, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("purl"); got != lastPURL {
+			http.Error(w, "purl mismatch: "+got, http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"name":"example","latest_release_number":"1.0.0","normalized_licenses":["MIT"]}]`))
+	})
+	mux.HandleFunc(ecosystemsRegistriesAPIPath+"/", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"example","latest_release_number":"1.0.0","normalized_licenses":["MIT"]}`))
+	})
+	server := httptest.NewServer(mux)
+	f.Cleanup(server.Close)
+
+	services := []*EcosystemsService{
+		NewEcosystemsService(EcosystemsServiceOptions{BaseURL: server.URL}),
+		NewEcosystemsService(EcosystemsServiceOptions{BaseURL: server.URL, PreferRegistryEndpoint: true}),
+	}
+	mock := &mockService{info: PackageInfo{Name: "example", Version: "1.0.0", Licenses: []string{"MIT"}}}
+
+	f.Fuzz(func(t *testing.T, purlString string) {
+		purl, err := packageurl.FromString(purlString)
+		if err != nil {
+			return
+		}
+		lastPURL = purl.String()
+
+		if _, mockErr := mock.GetPackageInfo(context.Background(), purl); mockErr != nil {
+			t.Fatalf("mockService.GetPackageInfo() error = %v", mockErr)
+		}
+
+		for _, service := range services {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			info, lookupErr := service.GetPackageInfo(ctx, purl)
+			cancel()
+			if lookupErr != nil {
+				t.Fatalf("GetPackageInfo(%q) error = %v", purl.String(), lookupErr)
+			}
+			info.OriginalPURL = purlString
+
+			if _, jsonErr := json.Marshal(info); jsonErr != nil {
+				t.Fatalf("json.Marshal() error = %v", jsonErr)
+			}
+			var buf bytes.Buffer
+			if printErr := printHumanReadableOutput(&buf, info); printErr != nil {
+				t.Fatalf("printHumanReadableOutput() error = %v", printErr)
+			}
+		}
+	})
+}
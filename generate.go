@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// generateCommand is the name of the generate subcommand.
+const generateCommand = "generate"
+
+// generateOutput is the JSON output of the generate subcommand.
+type generateOutput struct {
+	PURL string `json:"purl"`
+}
+
+// runGenerate runs the generate subcommand with its arguments.
+//
+// It prints the canonical purl of the package coordinates given as options, or as the name and
+// version arguments. With -verify, the service created by newService looks the package up first,
+// as a purl lookup does, to check that it exists; without -verify, no service is created.
+func runGenerate(cfg RunConfig, newService func() (Service, error), args []string) int {
+	flags := flag.NewFlagSet(generateCommand, flag.ContinueOnError)
+	flags.SetOutput(cfg.Stderr)
+	ecosystem := flags.String("ecosystem", "", "Package type of the purl (e.g., npm, pypi, maven) (required)")
+	namespace := flags.String("namespace", "", "Namespace of the package (e.g., the Maven group ID or npm scope)")
+	name := flags.String("name", "", "Name of the package (required, or as the first argument)")
+	version := flags.String("version", "", "Version of the package (optional, or as the second argument)")
+	verify := flags.Bool("verify", false, "Look up the package to check that it exists")
+	flags.Usage = func() {
+		fmt.Fprintf(cfg.Stderr, "Usage: %s [OPTIONS] generate [GENERATE OPTIONS]\n", os.Args[0])
+		fmt.Fprintf(cfg.Stderr, "       %s [OPTIONS] generate -ecosystem type [GENERATE OPTIONS] name [version]\n\n",
+			os.Args[0])
+		fmt.Fprintf(cfg.Stderr, "Print the canonical purl of a package.\n\n")
+		fmt.Fprintf(cfg.Stderr, "Without -namespace, the namespace is taken from the name where the ecosystem has one:\n")
+		fmt.Fprintf(cfg.Stderr, "@scope/name for npm, groupId:artifactId for maven, the module path for golang.\n\n")
+		fmt.Fprintf(cfg.Stderr, "Generate options:\n")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return exitInvalidArgs
+	}
+	// The name and version may be given as arguments instead of options
+	positional := flags.Args()
+	if *name == "" && len(positional) > 0 {
+		*name, positional = positional[0], positional[1:]
+		if *version == "" && len(positional) > 0 {
+			*version, positional = positional[0], positional[1:]
+		}
+	}
+	if len(positional) != 0 {
+		fmt.Fprintf(cfg.Stderr, "Error: Unexpected arguments: %v\n\n", positional)
+		flags.Usage()
+		return exitInvalidArgs
+	}
+	if *ecosystem == "" || *name == "" {
+		fmt.Fprintf(cfg.Stderr, "Error: -ecosystem and a name are required\n\n")
+		flags.Usage()
+		return exitInvalidArgs
+	}
+
+	if *namespace == "" {
+		*namespace, *name = splitPackageName(*ecosystem, *name)
+	}
+
+	purl := packageurl.NewPackageURL(*ecosystem, *namespace, *name, *version, nil, "")
+	if err := purl.Normalize(); err != nil {
+		fmt.Fprintf(cfg.Stderr, "Error: Invalid purl: %v\n", err)
+		return exitInvalidPurl
+	}
+	purlString := purl.String()
+
+	if *verify {
+		if exitCode := verifyGeneratedPURL(cfg, newService, *purl); exitCode != exitSuccess {
+			return exitCode
+		}
+	}
+
+	if printErr := printGenerateOutput(cfg.Stdout, purlString, cfg.Format); printErr != nil {
+		fmt.Fprintf(cfg.Stderr, "Error: %v\n", printErr)
+		return exitRuntimeError
+	}
+
+	return exitSuccess
+}
+
+// verifyGeneratedPURL looks up the generated purl with the service created by newService, normalized and
+// with its version resolved as for a purl lookup (see normalizePURL and lookupPURL).
+func verifyGeneratedPURL(cfg RunConfig, newService func() (Service, error), purl packageurl.PackageURL) int {
+	service, err := newService()
+	if err != nil {
+		fmt.Fprintf(cfg.Stderr, "Error: %v\n", err)
+		return exitInvalidArgs
+	}
+
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	purlString := purl.String()
+	cfg.Logger.Debug("verifying package", "purl", purlString)
+	if _, err := service.GetPackageInfo(ctx, lookupPURL(normalizePURL(cfg.Logger, purl))); err != nil {
+		return commandError(cfg, "Failed to verify package "+purlString, err)
+	}
+	return exitSuccess
+}
+
+// splitPackageName splits a package name as written in the ecosystem into the namespace and name of its purl.
+//
+// npm scoped packages are written @scope/name, Maven artifacts groupId:artifactId, and Go modules,
+// GitHub repositories and Composer packages by their path, whose last segment is the name. Other names
+// have no namespace.
+func splitPackageName(purlType, name string) (string, string) {
+	switch strings.ToLower(purlType) {
+	case packageurl.TypeNPM:
+		if scope, pkg, ok := strings.Cut(name, "/"); ok && strings.HasPrefix(scope, "@") {
+			return scope, pkg
+		}
+	case packageurl.TypeMaven:
+		if groupID, artifactID, ok := strings.Cut(name, ":"); ok {
+			return groupID, artifactID
+		}
+	case packageurl.TypeGolang, packageurl.TypeGithub, packageurl.TypeComposer:
+		if i := strings.LastIndex(name, "/"); i > 0 {
+			return name[:i], name[i+1:]
+		}
+	}
+	return "", name
+}
+
+// printGenerateOutput prints the generated purl to w in the given output format.
+func printGenerateOutput(w io.Writer, purlString string, format string) error {
+	if format == formatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(generateOutput{PURL: purlString}); encodeErr != nil {
+			return fmt.Errorf("failed to encode JSON: %w", encodeErr)
+		}
+		return nil
+	}
+
+	fmt.Fprintln(w, purlString)
+	return nil
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// TestRunGenerate tests the runGenerate function.
+func TestRunGenerate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		service    Service
+		setupErr   error
+		args       []string
+		format     string
+		want       int
+		wantStdout string
+	}{
+		{
+			name:       "name and version",
+			service:    &mockService{},
+			args:       []string{"-ecosystem", "npm", "-name", "lodash", "-version", "4.17.21"},
+			format:     formatText,
+			want:       exitSuccess,
+			wantStdout: "pkg:npm/lodash@4.17.21\n",
+		},
+		{
+			name:       "namespace",
+			service:    &mockService{},
+			args:       []string{"-ecosystem", "maven", "-namespace", "org.apache.commons", "-name", "commons-lang3"},
+			format:     formatText,
+			want:       exitSuccess,
+			wantStdout: "pkg:maven/org.apache.commons/commons-lang3\n",
+		},
+		{
+			name:       "npm scope is escaped",
+			service:    &mockService{},
+			args:       []string{"-ecosystem", "npm", "-namespace", "@types", "-name", "node", "-version", "18.0.0"},
+			format:     formatText,
+			want:       exitSuccess,
+			wantStdout: "pkg:npm/%40types/node@18.0.0\n",
+		},
+		{
+			name:       "type and name are normalized",
+			service:    &mockService{},
+			args:       []string{"-ecosystem", "PyPI", "-name", "Django_REST", "-version", "3.14.0"},
+			format:     formatText,
+			want:       exitSuccess,
+			wantStdout: "pkg:pypi/django-rest@3.14.0\n",
+		},
+		{
+			name:       "JSON output",
+			service:    &mockService{},
+			args:       []string{"-ecosystem", "npm", "-name", "lodash"},
+			format:     formatJSON,
+			want:       exitSuccess,
+			wantStdout: "{\n  \"purl\": \"pkg:npm/lodash\"\n}\n",
+		},
+		{
+			name:       "verified package",
+			service:    &mockService{info: PackageInfo{Name: "lodash"}},
+			args:       []string{"-ecosystem", "npm", "-name", "lodash", "-verify"},
+			format:     formatText,
+			want:       exitSuccess,
+			wantStdout: "pkg:npm/lodash\n",
+		},
+		{
+			name:    "verified package not found",
+			service: &mockService{err: ErrPackageNotFound},
+			args:    []string{"-ecosystem", "npm", "-name", "does-not-exist", "-verify"},
+			format:  formatText,
+			want:    exitNotFound,
+		},
+		{
+			name:       "not verified without -verify",
+			service:    &mockService{err: ErrPackageNotFound},
+			args:       []string{"-ecosystem", "npm", "-name", "does-not-exist"},
+			format:     formatText,
+			want:       exitSuccess,
+			wantStdout: "pkg:npm/does-not-exist\n",
+		},
+		{
+			name:       "service not created without -verify",
+			setupErr:   errors.New("unknown backend"),
+			args:       []string{"-ecosystem", "npm", "-name", "lodash"},
+			format:     formatText,
+			want:       exitSuccess,
+			wantStdout: "pkg:npm/lodash\n",
+		},
+		{
+			name:     "service setup error with -verify",
+			setupErr: errors.New("unknown backend"),
+			args:     []string{"-ecosystem", "npm", "-name", "lodash", "-verify"},
+			format:   formatText,
+			want:     exitInvalidArgs,
+		},
+		{
+			name:       "name and version arguments",
+			service:    &mockService{},
+			args:       []string{"-ecosystem", "npm", "lodash", "4.17.21"},
+			format:     formatText,
+			want:       exitSuccess,
+			wantStdout: "pkg:npm/lodash@4.17.21\n",
+		},
+		{
+			name:       "npm scope in the name",
+			service:    &mockService{},
+			args:       []string{"-ecosystem", "npm", "@types/node", "18.0.0"},
+			format:     formatText,
+			want:       exitSuccess,
+			wantStdout: "pkg:npm/%40types/node@18.0.0\n",
+		},
+		{
+			name:       "Maven groupId:artifactId name",
+			service:    &mockService{},
+			args:       []string{"-ecosystem", "maven", "-name", "org.apache.commons:commons-lang3", "-version", "3.12.0"},
+			format:     formatText,
+			want:       exitSuccess,
+			wantStdout: "pkg:maven/org.apache.commons/commons-lang3@3.12.0\n",
+		},
+		{
+			name:       "Go module path",
+			service:    &mockService{},
+			args:       []string{"-ecosystem", "golang", "github.com/spf13/cobra", "v1.8.0"},
+			format:     formatText,
+			want:       exitSuccess,
+			wantStdout: "pkg:golang/github.com/spf13/cobra@v1.8.0\n",
+		},
+		{
+			name:       "special characters are percent-encoded",
+			service:    &mockService{},
+			args:       []string{"-ecosystem", "generic", "my package", "1.0+build"},
+			format:     formatText,
+			want:       exitSuccess,
+			wantStdout: "pkg:generic/my%20package@1.0%2Bbuild\n",
+		},
+		{
+			name:    "too many arguments",
+			service: &mockService{},
+			args:    []string{"-ecosystem", "npm", "lodash", "4.17.21", "extra"},
+			want:    exitInvalidArgs,
+		},
+		{
+			name:    "missing name",
+			service: &mockService{},
+			args:    []string{"-ecosystem", "npm"},
+			want:    exitInvalidArgs,
+		},
+		{
+			name:    "missing ecosystem",
+			service: &mockService{},
+			args:    []string{"-name", "lodash"},
+			want:    exitInvalidArgs,
+		},
+		{
+			name:    "unexpected argument",
+			service: &mockService{},
+			args:    []string{"-ecosystem", "npm", "-name", "lodash", "extra"},
+			want:    exitInvalidArgs,
+		},
+		{
+			name:    "invalid type",
+			service: &mockService{},
+			args:    []string{"-ecosystem", "not a type", "-name", "lodash"},
+			want:    exitInvalidPurl,
+		},
+		{
+			name:    "missing required namespace",
+			service: &mockService{},
+			args:    []string{"-ecosystem", "swift", "-name", "swift-nio", "-version", "2.0.0"},
+			want:    exitInvalidPurl,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, stdout, stderr := newTestRunConfig(false, tt.format)
+
+			exitCode := runGenerate(cfg, func() (Service, error) { return tt.service, tt.setupErr }, tt.args)
+
+			if exitCode != tt.want {
+				t.Errorf("runGenerate() = %d, want %d\nStderr: %s", exitCode, tt.want, stderr.String())
+			}
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("runGenerate() output = %q, want %q", stdout.String(), tt.wantStdout)
+			}
+			if tt.want != exitSuccess && !strings.Contains(stderr.String(), "Error:") {
+				t.Errorf("runGenerate() stderr = %q, want an error message", stderr.String())
+			}
+		})
+	}
+}
+
+// lookupRecordingService is a Service recording the purl it looked up.
+type lookupRecordingService struct {
+	purl packageurl.PackageURL
+}
+
+func (s *lookupRecordingService) GetPackageInfo(_ context.Context, purl packageurl.PackageURL) (PackageInfo, error) {
+	s.purl = purl
+	return PackageInfo{Name: purl.Name}, nil
+}
+
+// TestRunGenerate_VerifyLookupPURL tests that -verify looks the package up as a purl lookup does.
+func TestRunGenerate_VerifyLookupPURL(t *testing.T) {
+	t.Parallel()
+
+	cfg, stdout, stderr := newTestRunConfig(false, formatText)
+	service := &lookupRecordingService{}
+	args := []string{"-ecosystem", "pypi", "-name", "zope.interface", "-version", "latest", "-verify"}
+
+	if exitCode := runGenerate(cfg, func() (Service, error) { return service, nil }, args); exitCode != exitSuccess {
+		t.Fatalf("runGenerate() = %d, want %d\nStderr: %s", exitCode, exitSuccess, stderr.String())
+	}
+	if want := "pkg:pypi/zope.interface@latest\n"; stdout.String() != want {
+		t.Errorf("runGenerate() output = %q, want %q", stdout.String(), want)
+	}
+	if got, want := service.purl.String(), "pkg:pypi/zope-interface"; got != want {
+		t.Errorf("looked up purl = %q, want %q", got, want)
+	}
+}
+
+// TestSplitPackageName tests the splitPackageName function.
+func TestSplitPackageName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		purlType      string
+		name          string
+		wantNamespace string
+		wantName      string
+	}{
+		{purlType: "npm", name: "@types/node", wantNamespace: "@types", wantName: "node"},
+		{purlType: "npm", name: "lodash", wantNamespace: "", wantName: "lodash"},
+		{purlType: "npm", name: "not-a-scope/pkg", wantNamespace: "", wantName: "not-a-scope/pkg"},
+		{purlType: "maven", name: "junit:junit", wantNamespace: "junit", wantName: "junit"},
+		{purlType: "Maven", name: "org.apache.commons:commons-lang3", wantNamespace: "org.apache.commons",
+			wantName: "commons-lang3"},
+		{purlType: "golang", name: "golang.org/x/sync", wantNamespace: "golang.org/x", wantName: "sync"},
+		{purlType: "github", name: "actions/checkout", wantNamespace: "actions", wantName: "checkout"},
+		{purlType: "composer", name: "laravel/framework", wantNamespace: "laravel", wantName: "framework"},
+		{purlType: "pypi", name: "requests", wantNamespace: "", wantName: "requests"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.purlType+"/"+tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotNamespace, gotName := splitPackageName(tt.purlType, tt.name)
+			if gotNamespace != tt.wantNamespace || gotName != tt.wantName {
+				t.Errorf("splitPackageName(%q, %q) = %q, %q, want %q, %q",
+					tt.purlType, tt.name, gotNamespace, gotName, tt.wantNamespace, tt.wantName)
+			}
+		})
+	}
+}
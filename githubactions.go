@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+const (
+	// githubActionsPurlType is the purl type of GitHub Actions, which packageurl-go does not define.
+	githubActionsPurlType = "githubactions"
+	// githubNoAssertionLicense is the SPDX ID reported by GitHub for licenses it cannot identify.
+	githubNoAssertionLicense = "NOASSERTION"
+	// minCommitSHALength is the length of the shortest abbreviated commit SHA looked up as a version.
+	minCommitSHALength = 7
+	// maxCommitSHALength is the length of a full SHA-1 commit SHA.
+	maxCommitSHALength = 40
+)
+
+// GitHubActionsService is the service for GitHub Actions, using the repositories of the GitHub REST API.
+//
+// An action is the repository pkg:githubactions/<owner>/<repo>, and its versions are the tags, branches and
+// commits of the repository.
+// The API does not require authentication, but the rate limit of anonymous requests is low.
+type GitHubActionsService struct {
+	baseURL string
+	client  *http.Client
+}
+
+var _ Service = (*GitHubActionsService)(nil)
+
+// GitHubActionsServiceOptions are the options for the GitHubActionsService.
+type GitHubActionsServiceOptions struct {
+	// BaseURL is the base URL for the GitHub REST API.
+	// If empty, defaults to the public GitHub API.
+	BaseURL string
+	// Client is the HTTP client to use for the GitHub REST API.
+	// If nil, defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// NewGitHubActionsService creates a new GitHubActionsService.
+func NewGitHubActionsService(opts GitHubActionsServiceOptions) *GitHubActionsService {
+	// Default to the GitHub API base URL.
+	baseURL := githubAPIBaseURL
+	if opts.BaseURL != "" {
+		baseURL = opts.BaseURL
+	}
+	// Default to the default HTTP client.
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &GitHubActionsService{
+		baseURL: baseURL,
+		client:  client,
+	}
+}
+
+// githubRepositoryResponse is the response from the GitHub repositories endpoint.
+type githubRepositoryResponse struct {
+	FullName    string  `json:"full_name"`
+	HTMLURL     string  `json:"html_url"`
+	Description *string `json:"description"`
+	Homepage    *string `json:"homepage"`
+	License     *struct {
+		SPDXID string `json:"spdx_id"`
+	} `json:"license"`
+}
+
+// githubReleaseResponse is the response from the GitHub latest release endpoint.
+type githubReleaseResponse struct {
+	TagName string `json:"tag_name"`
+}
+
+// GetPackageInfo returns the information about a GitHub Action.
+//
+// If the purl has a version, it must be a tag, a branch or a commit SHA of the repository. Otherwise,
+// the version is the tag of the latest release, or empty if the repository has no releases.
+func (s *GitHubActionsService) GetPackageInfo(ctx context.Context, purl packageurl.PackageURL) (PackageInfo, error) {
+	if purl.Type != githubActionsPurlType {
+		return PackageInfo{}, fmt.Errorf("%w: %s", ErrUnsupportedEcosystem, purl.Type)
+	}
+	if purl.Namespace == "" {
+		return PackageInfo{}, fmt.Errorf("%w: missing the owner of %s", ErrInvalidPURL, purl.Name)
+	}
+
+	repoURL := fmt.Sprintf("%s/repos/%s/%s", s.baseURL, url.PathEscape(purl.Namespace), url.PathEscape(purl.Name))
+
+	var repo githubRepositoryResponse
+	if err := getJSON(ctx, s.client, repoURL, githubAPIHeader(), &repo); err != nil {
+		return PackageInfo{}, err
+	}
+
+	version, err := s.getVersion(ctx, repoURL, purl.Version)
+	if err != nil {
+		return PackageInfo{}, err
+	}
+
+	licenses := []string{}
+	if repo.License != nil && repo.License.SPDXID != "" && repo.License.SPDXID != githubNoAssertionLicense {
+		licenses = []string{repo.License.SPDXID}
+	}
+
+	return PackageInfo{
+		Name:          repo.FullName,
+		Version:       version,
+		Licenses:      licenses,
+		Homepage:      stringValue(repo.Homepage),
+		RepositoryURL: repo.HTMLURL,
+		Description:   stringValue(repo.Description),
+		Ecosystem:     purl.Type,
+	}, nil
+}
+
+// getVersion returns the version of the action at repoURL: the given version if it is a tag, a branch or
+// a commit SHA of the repository, or the tag of the latest release if no version is given.
+func (s *GitHubActionsService) getVersion(ctx context.Context, repoURL, version string) (string, error) {
+	if version == "" {
+		var release githubReleaseResponse
+		if err := getJSON(ctx, s.client, repoURL+"/releases/latest", githubAPIHeader(), &release); err != nil {
+			// Actions without releases are referenced by branch or commit
+			if errors.Is(err, ErrPackageNotFound) {
+				return "", nil
+			}
+			return "", err
+		}
+		return release.TagName, nil
+	}
+
+	// Workflows reference actions by tag, branch or commit SHA, in this order of precedence
+	refURLs := []string{
+		repoURL + "/git/ref/tags/" + escapeGitRef(version),
+		repoURL + "/git/ref/heads/" + escapeGitRef(version),
+	}
+	if isCommitSHA(version) {
+		refURLs = append(refURLs, repoURL+"/commits/"+url.PathEscape(version))
+	}
+	for _, refURL := range refURLs {
+		var ref struct{}
+		err := getJSON(ctx, s.client, refURL, githubAPIHeader(), &ref)
+		if err == nil {
+			return version, nil
+		}
+		// GitHub responds with 422 to a commit SHA that is not in the repository
+		var statusErr *StatusError
+		unknownCommit := errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusUnprocessableEntity
+		if !errors.Is(err, ErrPackageNotFound) && !unknownCommit {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("%w: no tag, branch or commit %s", ErrPackageNotFound, version)
+}
+
+// escapeGitRef escapes each segment of a Git ref name, keeping the slashes of names such as releases/v1.
+func escapeGitRef(ref string) string {
+	segments := strings.Split(ref, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// isCommitSHA reports whether version may be an abbreviated or full commit SHA.
+func isCommitSHA(version string) bool {
+	if len(version) < minCommitSHALength || len(version) > maxCommitSHALength {
+		return false
+	}
+	for _, r := range version {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// TestNewGitHubActionsService tests the NewGitHubActionsService function.
+func TestNewGitHubActionsService(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default options", func(t *testing.T) {
+		t.Parallel()
+
+		service := NewGitHubActionsService(GitHubActionsServiceOptions{})
+
+		if service.baseURL != githubAPIBaseURL {
+			t.Errorf("baseURL = %q, want %q", service.baseURL, githubAPIBaseURL)
+		}
+		if service.client != http.DefaultClient {
+			t.Error("client should be http.DefaultClient when not provided")
+		}
+	})
+
+	t.Run("custom base URL", func(t *testing.T) {
+		t.Parallel()
+
+		customURL := "https://github.example.com/api/v3"
+		service := NewGitHubActionsService(GitHubActionsServiceOptions{
+			BaseURL: customURL,
+		})
+
+		if service.baseURL != customURL {
+			t.Errorf("baseURL = %q, want %q", service.baseURL, customURL)
+		}
+	})
+}
+
+// TestGitHubActionsService_GetPackageInfo tests the GetPackageInfo method.
+func TestGitHubActionsService_GetPackageInfo(t *testing.T) {
+	t.Parallel()
+
+	const checkoutRepo = `{
+		"full_name": "actions/checkout",
+		"html_url": "https://github.com/actions/checkout",
+		"description": "Action for checking out a repo",
+		"homepage": "https://github.com/features/actions",
+		"license": {"spdx_id": "MIT"}
+	}`
+
+	// mockResponse is the status code and body of the response to a path.
+	type mockResponse struct {
+		statusCode int
+		body       string
+	}
+
+	tests := []struct {
+		name      string
+		responses map[string]mockResponse
+		purl      string
+		want      PackageInfo
+		wantErr   error
+	}{
+		{
+			name: "action with tag",
+			responses: map[string]mockResponse{
+				"/repos/actions/checkout":                 {http.StatusOK, checkoutRepo},
+				"/repos/actions/checkout/git/ref/tags/v4": {http.StatusOK, `{"ref": "refs/tags/v4"}`},
+			},
+			purl: "pkg:githubactions/actions/checkout@v4",
+			want: PackageInfo{
+				Name:          "actions/checkout",
+				Version:       "v4",
+				Licenses:      []string{"MIT"},
+				Homepage:      "https://github.com/features/actions",
+				RepositoryURL: "https://github.com/actions/checkout",
+				Description:   "Action for checking out a repo",
+				Ecosystem:     "githubactions",
+			},
+		},
+		{
+			name: "action without version uses latest release",
+			responses: map[string]mockResponse{
+				"/repos/actions/checkout":                 {http.StatusOK, checkoutRepo},
+				"/repos/actions/checkout/releases/latest": {http.StatusOK, `{"tag_name": "v4.2.2"}`},
+			},
+			purl: "pkg:githubactions/actions/checkout",
+			want: PackageInfo{
+				Name:          "actions/checkout",
+				Version:       "v4.2.2",
+				Licenses:      []string{"MIT"},
+				Homepage:      "https://github.com/features/actions",
+				RepositoryURL: "https://github.com/actions/checkout",
+				Description:   "Action for checking out a repo",
+				Ecosystem:     "githubactions",
+			},
+		},
+		{
+			name: "action without releases or license",
+			responses: map[string]mockResponse{
+				"/repos/octo/hello": {http.StatusOK, `{
+					"full_name": "octo/hello",
+					"html_url": "https://github.com/octo/hello",
+					"license": {"spdx_id": "NOASSERTION"}
+				}`},
+				"/repos/octo/hello/releases/latest": {http.StatusNotFound, `{"message": "Not Found"}`},
+			},
+			purl: "pkg:githubactions/octo/hello",
+			want: PackageInfo{
+				Name:          "octo/hello",
+				Licenses:      []string{},
+				RepositoryURL: "https://github.com/octo/hello",
+				Ecosystem:     "githubactions",
+			},
+		},
+		{
+			name: "action with branch",
+			responses: map[string]mockResponse{
+				"/repos/actions/checkout":                           {http.StatusOK, checkoutRepo},
+				"/repos/actions/checkout/git/ref/tags/releases/v1":  {http.StatusNotFound, `{"message": "Not Found"}`},
+				"/repos/actions/checkout/git/ref/heads/releases/v1": {http.StatusOK, `{"ref": "refs/heads/releases/v1"}`},
+			},
+			purl: "pkg:githubactions/actions/checkout@releases%2Fv1",
+			want: PackageInfo{
+				Name:          "actions/checkout",
+				Version:       "releases/v1",
+				Licenses:      []string{"MIT"},
+				Homepage:      "https://github.com/features/actions",
+				RepositoryURL: "https://github.com/actions/checkout",
+				Description:   "Action for checking out a repo",
+				Ecosystem:     "githubactions",
+			},
+		},
+		{
+			name: "action with commit SHA",
+			responses: map[string]mockResponse{
+				"/repos/actions/checkout":                       {http.StatusOK, checkoutRepo},
+				"/repos/actions/checkout/git/ref/tags/11bd719":  {http.StatusNotFound, `{"message": "Not Found"}`},
+				"/repos/actions/checkout/git/ref/heads/11bd719": {http.StatusNotFound, `{"message": "Not Found"}`},
+				"/repos/actions/checkout/commits/11bd719": {
+					http.StatusOK, `{"sha": "11bd71901bbe5b1630ceea73d27597364c9af683"}`,
+				},
+			},
+			purl: "pkg:githubactions/actions/checkout@11bd719",
+			want: PackageInfo{
+				Name:          "actions/checkout",
+				Version:       "11bd719",
+				Licenses:      []string{"MIT"},
+				Homepage:      "https://github.com/features/actions",
+				RepositoryURL: "https://github.com/actions/checkout",
+				Description:   "Action for checking out a repo",
+				Ecosystem:     "githubactions",
+			},
+		},
+		{
+			name: "missing tag",
+			responses: map[string]mockResponse{
+				"/repos/actions/checkout":                   {http.StatusOK, checkoutRepo},
+				"/repos/actions/checkout/git/ref/tags/v99":  {http.StatusNotFound, `{"message": "Not Found"}`},
+				"/repos/actions/checkout/git/ref/heads/v99": {http.StatusNotFound, `{"message": "Not Found"}`},
+			},
+			purl:    "pkg:githubactions/actions/checkout@v99",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name: "missing commit",
+			responses: map[string]mockResponse{
+				"/repos/actions/checkout":                        {http.StatusOK, checkoutRepo},
+				"/repos/actions/checkout/git/ref/tags/deadbeef":  {http.StatusNotFound, `{"message": "Not Found"}`},
+				"/repos/actions/checkout/git/ref/heads/deadbeef": {http.StatusNotFound, `{"message": "Not Found"}`},
+				"/repos/actions/checkout/commits/deadbeef": {
+					http.StatusUnprocessableEntity, `{"message": "No commit found for SHA: deadbeef"}`,
+				},
+			},
+			purl:    "pkg:githubactions/actions/checkout@deadbeef",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name: "missing repository",
+			responses: map[string]mockResponse{
+				"/repos/octo/missing": {http.StatusNotFound, `{"message": "Not Found"}`},
+			},
+			purl:    "pkg:githubactions/octo/missing@v1",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name:    "missing owner",
+			purl:    "pkg:githubactions/checkout@v4",
+			wantErr: ErrInvalidPURL,
+		},
+		{
+			name: "malformed JSON",
+			responses: map[string]mockResponse{
+				"/repos/actions/checkout": {http.StatusOK, `{invalid json}`},
+			},
+			purl:    "pkg:githubactions/actions/checkout@v4",
+			wantErr: ErrInvalidResponse,
+		},
+		{
+			name:    "unsupported ecosystem",
+			purl:    "pkg:npm/lodash@4.17.21",
+			wantErr: ErrUnsupportedEcosystem,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Accept") != "application/vnd.github+json" {
+					t.Errorf("Accept = %q, want application/vnd.github+json", r.Header.Get("Accept"))
+				}
+
+				response, ok := tt.responses[r.URL.Path]
+				if !ok {
+					t.Errorf("unexpected request path %q", r.URL.Path)
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(response.statusCode)
+				_, _ = w.Write([]byte(response.body))
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewGitHubActionsService(GitHubActionsServiceOptions{
+				BaseURL: server.URL,
+			})
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.GetPackageInfo(context.Background(), purl)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetPackageInfo() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+
+			if got.Name != tt.want.Name {
+				t.Errorf("GetPackageInfo() Name = %q, want %q", got.Name, tt.want.Name)
+			}
+			if got.Version != tt.want.Version {
+				t.Errorf("GetPackageInfo() Version = %q, want %q", got.Version, tt.want.Version)
+			}
+			if got.Ecosystem != tt.want.Ecosystem {
+				t.Errorf("GetPackageInfo() Ecosystem = %q, want %q", got.Ecosystem, tt.want.Ecosystem)
+			}
+			if !equalStringSlices(got.Licenses, tt.want.Licenses) {
+				t.Errorf("GetPackageInfo() Licenses = %v, want %v", got.Licenses, tt.want.Licenses)
+			}
+			if got.Homepage != tt.want.Homepage {
+				t.Errorf("GetPackageInfo() Homepage = %q, want %q", got.Homepage, tt.want.Homepage)
+			}
+			if got.RepositoryURL != tt.want.RepositoryURL {
+				t.Errorf("GetPackageInfo() RepositoryURL = %q, want %q", got.RepositoryURL, tt.want.RepositoryURL)
+			}
+			if got.Description != tt.want.Description {
+				t.Errorf("GetPackageInfo() Description = %q, want %q", got.Description, tt.want.Description)
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+const (
+	// githubAPIBaseURL is the base URL for the GitHub REST API.
+	//
+	// See https://docs.github.com/en/rest/packages/packages
+	githubAPIBaseURL = "https://api.github.com"
+	// githubPackagesAPIPath is the API path for packages of the authenticated user.
+	githubPackagesAPIPath = "/user/packages"
+	// githubOrgsAPIPath is the API path for organizations, whose packages are under {org}/packages.
+	githubOrgsAPIPath = "/orgs"
+	// githubUsersAPIPath is the API path for users, whose packages are under {user}/packages.
+	githubUsersAPIPath = "/users"
+	// githubAPIVersion is the GitHub REST API version requested.
+	githubAPIVersion = "2022-11-28"
+	// githubVersionsPerPage is the number of package versions requested per page, the maximum of the API.
+	githubVersionsPerPage = 100
+	// githubLatestTag is the container tag that names the newest image rather than a version.
+	githubLatestTag = "latest"
+)
+
+// GitHubPackagesService is the service for the GitHub Packages API.
+//
+// The GitHub Packages API requires authentication, so the HTTP client must
+// send a token (see AuthMiddleware).
+type GitHubPackagesService struct {
+	baseURL string
+	client  *http.Client
+}
+
+var _ Service = (*GitHubPackagesService)(nil)
+
+// GitHubPackagesServiceOptions are the options for the GitHubPackagesService.
+type GitHubPackagesServiceOptions struct {
+	// BaseURL is the base URL for the GitHub REST API.
+	// If empty, defaults to the public GitHub API.
+	BaseURL string
+	// Client is the HTTP client to use for the GitHub REST API.
+	// If nil, defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// NewGitHubPackagesService creates a new GitHubPackagesService.
+func NewGitHubPackagesService(opts GitHubPackagesServiceOptions) *GitHubPackagesService {
+	// Default to the GitHub API base URL.
+	baseURL := githubAPIBaseURL
+	if opts.BaseURL != "" {
+		baseURL = opts.BaseURL
+	}
+	// Default to the default HTTP client.
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &GitHubPackagesService{
+		baseURL: baseURL,
+		client:  client,
+	}
+}
+
+// githubPackageResponse is the response from the GitHub Packages API.
+type githubPackageResponse struct {
+	Name       string `json:"name"`
+	HTMLURL    string `json:"html_url"`
+	Repository *struct {
+		HTMLURL     string  `json:"html_url"`
+		Description *string `json:"description"`
+	} `json:"repository"`
+}
+
+// githubPackageVersionResponse is a version from the GitHub Packages versions endpoint.
+//
+// The name of a container image version is its digest; its tags are in the container metadata.
+type githubPackageVersionResponse struct {
+	Name     string `json:"name"`
+	Metadata struct {
+		Container *struct {
+			Tags []string `json:"tags"`
+		} `json:"container"`
+	} `json:"metadata"`
+}
+
+// tags returns the tags of a container image version, or nil for other packages.
+func (v githubPackageVersionResponse) tags() []string {
+	if v.Metadata.Container == nil {
+		return nil
+	}
+	return v.Metadata.Container.Tags
+}
+
+// githubAPIHeader returns the headers of the requests to the GitHub REST API, selecting its version.
+func githubAPIHeader() http.Header {
+	header := http.Header{}
+	header.Set("Accept", "application/vnd.github+json")
+	header.Set("X-Github-Api-Version", githubAPIVersion)
+	return header
+}
+
+// githubPackageType maps a purl type to a GitHub Packages package_type.
+func githubPackageType(purlType string) (string, bool) {
+	switch purlType {
+	case packageurl.TypeNPM:
+		return "npm", true
+	case packageurl.TypeMaven:
+		return "maven", true
+	case packageurl.TypeGem:
+		return "rubygems", true
+	case packageurl.TypeDocker:
+		return "docker", true
+	case packageurl.TypeNuget:
+		return "nuget", true
+	case packageurl.TypeOCI:
+		return "container", true
+	default:
+		return "", false
+	}
+}
+
+// githubPackageName returns the GitHub Packages package_name for a purl.
+//
+// Maven packages are named `groupId.artifactId`; npm packages drop the scope, and container images
+// the first segment of the namespace, which are the owner of the package (see githubPackageOwner).
+func githubPackageName(purl packageurl.PackageURL) string {
+	switch purl.Type {
+	case packageurl.TypeMaven:
+		if purl.Namespace != "" {
+			return purl.Namespace + "." + purl.Name
+		}
+	case packageurl.TypeDocker, packageurl.TypeOCI:
+		// Images nested under the owner (ghcr.io/owner/repo/image) are named repo/image
+		if _, rest, found := strings.Cut(purl.Namespace, "/"); found {
+			return rest + "/" + purl.Name
+		}
+	}
+	return purl.Name
+}
+
+// githubPackageOwner returns the user or organization owning the package of a purl, or an empty string
+// if the purl does not name one: the npm scope, or the first segment of the namespace of a container image.
+//
+// The namespace of Maven purls is the groupId, which is not an owner.
+func githubPackageOwner(purl packageurl.PackageURL) string {
+	switch purl.Type {
+	case packageurl.TypeNPM:
+		return strings.TrimPrefix(purl.Namespace, "@")
+	case packageurl.TypeMaven:
+		return ""
+	default:
+		owner, _, _ := strings.Cut(purl.Namespace, "/")
+		return owner
+	}
+}
+
+// GetPackageInfo returns the information about a package.
+//
+// The GitHub Packages API does not expose licenses, and the registry page is the GitHub package page.
+// The version of the purl, a version name or a container tag, is confirmed with the versions of the package;
+// without one, the newest version is used.
+// Packages with an owner (see githubPackageOwner) are looked up among the packages of the organization,
+// then of the user; others among the packages of the authenticated user.
+func (s *GitHubPackagesService) GetPackageInfo(ctx context.Context, purl packageurl.PackageURL) (PackageInfo, error) {
+	packageType, ok := githubPackageType(purl.Type)
+	if !ok {
+		return PackageInfo{}, fmt.Errorf("%w: %s", ErrUnsupportedEcosystem, purl.Type)
+	}
+
+	packagePath := fmt.Sprintf("%s/%s", packageType, url.PathEscape(githubPackageName(purl)))
+
+	owner := githubPackageOwner(purl)
+	packageURL := s.baseURL + githubPackagesAPIPath + "/" + packagePath
+	if owner != "" {
+		packageURL = s.ownerPackageURL(githubOrgsAPIPath, owner, packagePath)
+	}
+	var result githubPackageResponse
+	err := getJSON(ctx, s.client, packageURL, githubAPIHeader(), &result)
+	// Users are not organizations
+	if owner != "" && errors.Is(err, ErrPackageNotFound) {
+		packageURL = s.ownerPackageURL(githubUsersAPIPath, owner, packagePath)
+		err = getJSON(ctx, s.client, packageURL, githubAPIHeader(), &result)
+	}
+	if err != nil {
+		return PackageInfo{}, err
+	}
+
+	version, err := s.getVersion(ctx, packageURL, purl.Version)
+	if err != nil {
+		return PackageInfo{}, fmt.Errorf("%s: %w", result.Name, err)
+	}
+
+	packageInfo := PackageInfo{
+		Name:            result.Name,
+		Version:         version,
+		Licenses:        []string{},
+		Homepage:        result.HTMLURL,
+		Ecosystem:       purl.Type,
+		RegistryURL:     result.HTMLURL,
+		PrivateRegistry: true,
+	}
+	if result.Repository != nil {
+		packageInfo.RepositoryURL = result.Repository.HTMLURL
+		packageInfo.Description = stringValue(result.Repository.Description)
+	}
+
+	return packageInfo, nil
+}
+
+// getVersion returns version if it is the name or a container tag of a version of the package at packageURL,
+// or the newest version of the package if version is empty.
+//
+// The newest version of a container image is its first tag other than "latest", or its digest if it has none.
+func (s *GitHubPackagesService) getVersion(ctx context.Context, packageURL, version string) (string, error) {
+	// The versions are listed from the newest to the oldest
+	for page := 1; ; page++ {
+		var versions []githubPackageVersionResponse
+		versionsURL := fmt.Sprintf("%s/versions?per_page=%d&page=%d", packageURL, githubVersionsPerPage, page)
+		if err := getJSON(ctx, s.client, versionsURL, githubAPIHeader(), &versions); err != nil {
+			return "", err
+		}
+
+		for _, v := range versions {
+			if version == "" {
+				return githubNewestVersionName(v), nil
+			}
+			if v.Name == version || slices.Contains(v.tags(), version) {
+				return version, nil
+			}
+		}
+		if len(versions) < githubVersionsPerPage {
+			break
+		}
+	}
+
+	if version == "" {
+		return "", fmt.Errorf("%w: no versions", ErrPackageNotFound)
+	}
+	return "", fmt.Errorf("%w: version %s", ErrPackageNotFound, version)
+}
+
+// githubNewestVersionName returns the version name of the newest version of a package: its first tag other
+// than "latest" for a container image, else its name.
+func githubNewestVersionName(v githubPackageVersionResponse) string {
+	for _, tag := range v.tags() {
+		if tag != githubLatestTag {
+			return tag
+		}
+	}
+	return v.Name
+}
+
+// ownerPackageURL returns the URL of the package at packagePath ({package_type}/{package_name})
+// of the owner, an organization or a user depending on ownersPath.
+func (s *GitHubPackagesService) ownerPackageURL(ownersPath, owner, packagePath string) string {
+	return fmt.Sprintf("%s%s/%s/packages/%s", s.baseURL, ownersPath, url.PathEscape(owner), packagePath)
+}
@@ -0,0 +1,387 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// TestNewGitHubPackagesService tests the NewGitHubPackagesService function.
+func TestNewGitHubPackagesService(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default options", func(t *testing.T) {
+		t.Parallel()
+
+		service := NewGitHubPackagesService(GitHubPackagesServiceOptions{})
+
+		if service.baseURL != githubAPIBaseURL {
+			t.Errorf("baseURL = %q, want %q", service.baseURL, githubAPIBaseURL)
+		}
+		if service.client != http.DefaultClient {
+			t.Error("client should be http.DefaultClient when not provided")
+		}
+	})
+
+	t.Run("custom base URL", func(t *testing.T) {
+		t.Parallel()
+
+		customURL := "https://github.example.com/api/v3"
+		service := NewGitHubPackagesService(GitHubPackagesServiceOptions{
+			BaseURL: customURL,
+		})
+
+		if service.baseURL != customURL {
+			t.Errorf("baseURL = %q, want %q", service.baseURL, customURL)
+		}
+	})
+}
+
+// TestGitHubPackagesService_GetPackageInfo tests the GetPackageInfo method.
+func TestGitHubPackagesService_GetPackageInfo(t *testing.T) {
+	t.Parallel()
+
+	// mockResponse is the status code and body of the response to a path.
+	type mockResponse struct {
+		statusCode int
+		body       string
+	}
+
+	tests := []struct {
+		name      string
+		responses map[string]mockResponse
+		purl      string
+		want      PackageInfo
+		wantErr   error
+	}{
+		{
+			name: "npm package",
+			responses: map[string]mockResponse{
+				"/orgs/octo-org/packages/npm/hello-world": {http.StatusOK, `{
+					"name": "hello-world",
+					"package_type": "npm",
+					"html_url": "https://github.com/octo-org/hello-world/packages/1",
+					"repository": {
+						"html_url": "https://github.com/octo-org/hello-world",
+						"description": "Hello, world!"
+					}
+				}`},
+				"/orgs/octo-org/packages/npm/hello-world/versions": {http.StatusOK, `[
+					{"id": 2, "name": "1.1.0"},
+					{"id": 1, "name": "1.0.0"}
+				]`},
+			},
+			purl: "pkg:npm/%40octo-org/hello-world@1.0.0",
+			want: PackageInfo{
+				Name:          "hello-world",
+				Version:       "1.0.0",
+				Licenses:      []string{},
+				Homepage:      "https://github.com/octo-org/hello-world/packages/1",
+				RegistryURL:   "https://github.com/octo-org/hello-world/packages/1",
+				RepositoryURL: "https://github.com/octo-org/hello-world",
+				Description:   "Hello, world!",
+				Ecosystem:     "npm",
+			},
+		},
+		{
+			name: "package without version uses newest version",
+			responses: map[string]mockResponse{
+				"/orgs/octo-org/packages/npm/hello-world": {http.StatusOK, `{"name": "hello-world"}`},
+				"/orgs/octo-org/packages/npm/hello-world/versions": {http.StatusOK, `[
+					{"id": 2, "name": "1.1.0"},
+					{"id": 1, "name": "1.0.0"}
+				]`},
+			},
+			purl: "pkg:npm/%40octo-org/hello-world",
+			want: PackageInfo{
+				Name:      "hello-world",
+				Version:   "1.1.0",
+				Licenses:  []string{},
+				Ecosystem: "npm",
+			},
+		},
+		{
+			name: "unknown version",
+			responses: map[string]mockResponse{
+				"/orgs/octo-org/packages/npm/hello-world":          {http.StatusOK, `{"name": "hello-world"}`},
+				"/orgs/octo-org/packages/npm/hello-world/versions": {http.StatusOK, `[{"id": 1, "name": "1.0.0"}]`},
+			},
+			purl:    "pkg:npm/%40octo-org/hello-world@9.9.9",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name: "package without versions",
+			responses: map[string]mockResponse{
+				"/orgs/octo-org/packages/npm/hello-world":          {http.StatusOK, `{"name": "hello-world"}`},
+				"/orgs/octo-org/packages/npm/hello-world/versions": {http.StatusOK, `[]`},
+			},
+			purl:    "pkg:npm/%40octo-org/hello-world",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name: "maven package without repository",
+			responses: map[string]mockResponse{
+				"/user/packages/maven/com.example.demo": {http.StatusOK,
+					`{"name": "com.example.demo", "html_url": "https://github.com/users/octocat/packages/2"}`},
+				"/user/packages/maven/com.example.demo/versions": {http.StatusOK, `[{"id": 1, "name": "1.2.3"}]`},
+			},
+			purl: "pkg:maven/com.example/demo@1.2.3",
+			want: PackageInfo{
+				Name:        "com.example.demo",
+				Version:     "1.2.3",
+				Licenses:    []string{},
+				Homepage:    "https://github.com/users/octocat/packages/2",
+				RegistryURL: "https://github.com/users/octocat/packages/2",
+				Ecosystem:   "maven",
+			},
+		},
+		{
+			name: "container image nested under the owner",
+			responses: map[string]mockResponse{
+				"/orgs/octo-org/packages/container/app%2Fweb": {http.StatusOK, `{
+					"name": "app/web",
+					"html_url": "https://github.com/orgs/octo-org/packages/container/package/app%2Fweb"
+				}`},
+				"/orgs/octo-org/packages/container/app%2Fweb/versions": {http.StatusOK,
+					`[{"id": 1, "name": "sha256:abc", "metadata": {"container": {"tags": []}}}]`},
+			},
+			purl: "pkg:oci/octo-org/app/web@sha256%3Aabc",
+			want: PackageInfo{
+				Name:        "app/web",
+				Version:     "sha256:abc",
+				Licenses:    []string{},
+				Homepage:    "https://github.com/orgs/octo-org/packages/container/package/app%2Fweb",
+				RegistryURL: "https://github.com/orgs/octo-org/packages/container/package/app%2Fweb",
+				Ecosystem:   "oci",
+			},
+		},
+		{
+			name: "container image tag",
+			responses: map[string]mockResponse{
+				"/orgs/octo-org/packages/container/web": {http.StatusOK, `{"name": "web"}`},
+				"/orgs/octo-org/packages/container/web/versions": {http.StatusOK,
+					`[{"id": 1, "name": "sha256:abc", "metadata": {"container": {"tags": ["latest", "2.0"]}}}]`},
+			},
+			purl: "pkg:oci/octo-org/web@2.0",
+			want: PackageInfo{
+				Name:      "web",
+				Version:   "2.0",
+				Licenses:  []string{},
+				Ecosystem: "oci",
+			},
+		},
+		{
+			name: "container image without version uses newest tag",
+			responses: map[string]mockResponse{
+				"/orgs/octo-org/packages/container/web": {http.StatusOK, `{"name": "web"}`},
+				"/orgs/octo-org/packages/container/web/versions": {http.StatusOK,
+					`[{"id": 1, "name": "sha256:abc", "metadata": {"container": {"tags": ["latest", "2.0"]}}}]`},
+			},
+			purl: "pkg:oci/octo-org/web",
+			want: PackageInfo{
+				Name:      "web",
+				Version:   "2.0",
+				Licenses:  []string{},
+				Ecosystem: "oci",
+			},
+		},
+		{
+			name: "gem maps to rubygems",
+			responses: map[string]mockResponse{
+				"/user/packages/rubygems/octo-gem":          {http.StatusOK, `{"name": "octo-gem"}`},
+				"/user/packages/rubygems/octo-gem/versions": {http.StatusOK, `[{"id": 1, "name": "0.1.0"}]`},
+			},
+			purl: "pkg:gem/octo-gem@0.1.0",
+			want: PackageInfo{
+				Name:      "octo-gem",
+				Version:   "0.1.0",
+				Licenses:  []string{},
+				Ecosystem: "gem",
+			},
+		},
+		{
+			name: "HTTP 404 error",
+			responses: map[string]mockResponse{
+				"/user/packages/npm/missing": {http.StatusNotFound, `{"message": "Package not found."}`},
+			},
+			purl:    "pkg:npm/missing@1.0.0",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name: "malformed JSON",
+			responses: map[string]mockResponse{
+				"/user/packages/npm/test": {http.StatusOK, `{invalid json}`},
+			},
+			purl:    "pkg:npm/test@1.0.0",
+			wantErr: ErrInvalidResponse,
+		},
+		{
+			name:    "unsupported ecosystem",
+			purl:    "pkg:pypi/requests@2.28.0",
+			wantErr: ErrUnsupportedEcosystem,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Accept") != "application/vnd.github+json" {
+					t.Errorf("Accept = %q, want application/vnd.github+json", r.Header.Get("Accept"))
+				}
+				response, ok := tt.responses[r.URL.EscapedPath()]
+				if !ok {
+					t.Errorf("unexpected request path %q", r.URL.EscapedPath())
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(response.statusCode)
+				_, _ = w.Write([]byte(response.body))
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewGitHubPackagesService(GitHubPackagesServiceOptions{
+				BaseURL: server.URL,
+			})
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.GetPackageInfo(context.Background(), purl)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetPackageInfo() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+
+			if got.Name != tt.want.Name {
+				t.Errorf("GetPackageInfo() Name = %q, want %q", got.Name, tt.want.Name)
+			}
+			if got.Version != tt.want.Version {
+				t.Errorf("GetPackageInfo() Version = %q, want %q", got.Version, tt.want.Version)
+			}
+			if got.Ecosystem != tt.want.Ecosystem {
+				t.Errorf("GetPackageInfo() Ecosystem = %q, want %q", got.Ecosystem, tt.want.Ecosystem)
+			}
+			if !equalStringSlices(got.Licenses, tt.want.Licenses) {
+				t.Errorf("GetPackageInfo() Licenses = %v, want %v", got.Licenses, tt.want.Licenses)
+			}
+			if got.Homepage != tt.want.Homepage {
+				t.Errorf("GetPackageInfo() Homepage = %q, want %q", got.Homepage, tt.want.Homepage)
+			}
+			if got.RegistryURL != tt.want.RegistryURL {
+				t.Errorf("GetPackageInfo() RegistryURL = %q, want %q", got.RegistryURL, tt.want.RegistryURL)
+			}
+			if !got.PrivateRegistry {
+				t.Error("GetPackageInfo() PrivateRegistry = false, want true")
+			}
+			if got.RepositoryURL != tt.want.RepositoryURL {
+				t.Errorf("GetPackageInfo() RepositoryURL = %q, want %q", got.RepositoryURL, tt.want.RepositoryURL)
+			}
+			if got.Description != tt.want.Description {
+				t.Errorf("GetPackageInfo() Description = %q, want %q", got.Description, tt.want.Description)
+			}
+		})
+	}
+}
+
+// TestGitHubPackagesService_GetPackageInfo_UserOwner tests that the packages of a user owner are looked up
+// after the packages of the organization of that name are not found.
+func TestGitHubPackagesService_GetPackageInfo_UserOwner(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/users/octocat/packages/npm/hello-world/versions" {
+			_, _ = w.Write([]byte(`[{"id": 1, "name": "1.0.0"}]`))
+			return
+		}
+		if r.URL.Path != "/users/octocat/packages/npm/hello-world" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"name": "hello-world"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	service := NewGitHubPackagesService(GitHubPackagesServiceOptions{
+		BaseURL: server.URL,
+	})
+
+	purl, err := packageurl.FromString("pkg:npm/%40octocat/hello-world@1.0.0")
+	if err != nil {
+		t.Fatalf("failed to parse purl: %v", err)
+	}
+
+	got, err := service.GetPackageInfo(context.Background(), purl)
+	if err != nil {
+		t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+	}
+	if got.Name != "hello-world" {
+		t.Errorf("GetPackageInfo() Name = %q, want %q", got.Name, "hello-world")
+	}
+
+	wantPaths := []string{
+		"/orgs/octocat/packages/npm/hello-world",
+		"/users/octocat/packages/npm/hello-world",
+		"/users/octocat/packages/npm/hello-world/versions",
+	}
+	if !equalStringSlices(paths, wantPaths) {
+		t.Errorf("request paths = %v, want %v", paths, wantPaths)
+	}
+}
+
+// TestGitHubPackagesService_GetPackageInfo_VersionsPages tests that the version is looked up in the following pages
+// of versions when a page is full.
+func TestGitHubPackagesService_GetPackageInfo_VersionsPages(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user/packages/npm/hello-world/versions" {
+			_, _ = w.Write([]byte(`{"name": "hello-world"}`))
+			return
+		}
+		if r.URL.Query().Get("page") != "1" {
+			_, _ = w.Write([]byte(`[{"id": 1, "name": "0.1.0"}]`))
+			return
+		}
+		versions := make([]string, 0, githubVersionsPerPage)
+		for i := range githubVersionsPerPage {
+			versions = append(versions, fmt.Sprintf(`{"id": %d, "name": "1.0.%d"}`, i+2, i))
+		}
+		_, _ = w.Write([]byte("[" + strings.Join(versions, ",") + "]"))
+	}))
+	t.Cleanup(server.Close)
+
+	service := NewGitHubPackagesService(GitHubPackagesServiceOptions{
+		BaseURL: server.URL,
+	})
+
+	purl, err := packageurl.FromString("pkg:npm/hello-world@0.1.0")
+	if err != nil {
+		t.Fatalf("failed to parse purl: %v", err)
+	}
+
+	got, err := service.GetPackageInfo(context.Background(), purl)
+	if err != nil {
+		t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+	}
+	if got.Version != "0.1.0" {
+		t.Errorf("GetPackageInfo() Version = %q, want %q", got.Version, "0.1.0")
+	}
+}
@@ -2,4 +2,16 @@ module github.com/boringbin/purlinfo
 
 go 1.25.0
 
-require github.com/package-url/packageurl-go v0.1.3
+require (
+	github.com/BurntSushi/toml v1.6.0
+	github.com/atotto/clipboard v0.1.4
+	github.com/package-url/packageurl-go v0.1.3
+	github.com/zalando/go-keyring v0.2.8
+	golang.org/x/term v0.26.0
+)
+
+require (
+	github.com/danieljoos/wincred v1.2.3 // indirect
+	github.com/godbus/dbus/v5 v5.2.2 // indirect
+	golang.org/x/sys v0.27.0 // indirect
+)
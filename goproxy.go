@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/package-url/packageurl-go"
+)
+
+const (
+	// goProxyBaseURL is the base URL for the Go module proxy.
+	//
+	// See https://go.dev/ref/mod#goproxy-protocol
+	goProxyBaseURL = "https://proxy.golang.org"
+	// goProxyLatestVersion is the proxy endpoint resolving the latest version of a module.
+	goProxyLatestVersion = "@latest"
+	// maxGoModSize is the maximum size of a go.mod file read from the proxy.
+	maxGoModSize = 1 << 20
+)
+
+// GoModuleProxyService is the service for the Go module proxy protocol.
+//
+// The proxy only serves versions and go.mod files: licenses, descriptions and
+// project URLs are not available and would require fetching the module's source
+// from its VCS, which this service does not do.
+type GoModuleProxyService struct {
+	baseURL string
+	client  *http.Client
+}
+
+var _ Service = (*GoModuleProxyService)(nil)
+
+// GoModuleProxyServiceOptions are the options for the GoModuleProxyService.
+type GoModuleProxyServiceOptions struct {
+	// BaseURL is the base URL for the Go module proxy.
+	// If empty, defaults to proxy.golang.org.
+	BaseURL string
+	// Client is the HTTP client to use for the Go module proxy.
+	// If nil, defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// NewGoModuleProxyService creates a new GoModuleProxyService.
+func NewGoModuleProxyService(opts GoModuleProxyServiceOptions) *GoModuleProxyService {
+	// Default to the Go module proxy base URL.
+	baseURL := goProxyBaseURL
+	if opts.BaseURL != "" {
+		baseURL = opts.BaseURL
+	}
+	// Default to the default HTTP client.
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &GoModuleProxyService{
+		baseURL: baseURL,
+		client:  client,
+	}
+}
+
+// goProxyInfoResponse is the response from the `.info` and `@latest` proxy endpoints.
+type goProxyInfoResponse struct {
+	Version string `json:"Version"`
+}
+
+// goModulePath returns the module path of a golang purl.
+func goModulePath(purl packageurl.PackageURL) string {
+	if purl.Namespace == "" {
+		return purl.Name
+	}
+	return purl.Namespace + "/" + purl.Name
+}
+
+// escapeModulePath escapes a module path or version for use in a proxy URL.
+//
+// Uppercase letters are replaced by an exclamation mark followed by the lowercase letter.
+func escapeModulePath(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if unicode.IsUpper(r) {
+			b.WriteRune('!')
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// countGoModRequires counts the direct module requirements declared in a go.mod file, skipping
+// the requirements marked `// indirect`.
+func countGoModRequires(goMod string) int {
+	count := 0
+	inBlock := false
+	for line := range strings.Lines(goMod) {
+		indirect := false
+		if i := strings.Index(line, "//"); i >= 0 {
+			indirect = strings.HasPrefix(strings.TrimSpace(line[i+len("//"):]), "indirect")
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+
+		switch {
+		case len(fields) == 0:
+		case inBlock && fields[0] == ")":
+			inBlock = false
+		case inBlock && !indirect:
+			count++
+		case fields[0] == "require" && len(fields) > 1 && fields[1] == "(":
+			inBlock = true
+		case fields[0] == "require" && len(fields) > 1 && !indirect:
+			count++
+		}
+	}
+	return count
+}
+
+// GetPackageInfo returns the information about a package.
+//
+// If the purl has no version, the latest version known to the proxy is used.
+func (s *GoModuleProxyService) GetPackageInfo(ctx context.Context, purl packageurl.PackageURL) (PackageInfo, error) {
+	if purl.Type != packageurl.TypeGolang {
+		return PackageInfo{}, fmt.Errorf("%w: %s", ErrUnsupportedEcosystem, purl.Type)
+	}
+
+	modulePath := goModulePath(purl)
+	moduleURL := s.baseURL + "/" + escapeModulePath(modulePath)
+
+	infoURL := moduleURL + "/" + goProxyLatestVersion
+	if purl.Version != "" {
+		infoURL = fmt.Sprintf("%s/@v/%s.info", moduleURL, escapeModulePath(purl.Version))
+	}
+
+	var info goProxyInfoResponse
+	if err := getJSON(ctx, s.client, infoURL, nil, &info); err != nil {
+		return PackageInfo{}, err
+	}
+
+	goMod, err := s.getGoMod(ctx, fmt.Sprintf("%s/@v/%s.mod", moduleURL, escapeModulePath(info.Version)))
+	if err != nil {
+		return PackageInfo{}, err
+	}
+	dependencyCount := countGoModRequires(goMod)
+
+	return PackageInfo{
+		Name:            modulePath,
+		Version:         info.Version,
+		Licenses:        []string{},
+		Ecosystem:       purl.Type,
+		DependencyCount: &dependencyCount,
+	}, nil
+}
+
+// getGoMod returns the go.mod file at modURL.
+func (s *GoModuleProxyService) getGoMod(ctx context.Context, modURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, modURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent())
+
+	response, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to make HTTP request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", statusError(response.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxGoModSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read go.mod: %w", err)
+	}
+
+	return string(body), nil
+}
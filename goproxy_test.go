@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// goProxyTestMod is a canned go.mod file served by the mock proxy.
+const goProxyTestMod = `module github.com/BurntSushi/toml
+
+go 1.18
+
+require github.com/example/single v1.0.0 // indirect
+
+require (
+	github.com/example/a v1.0.0
+	// a comment line
+	github.com/example/b v1.2.0 // indirect
+)
+`
+
+// TestNewGoModuleProxyService tests the NewGoModuleProxyService function.
+func TestNewGoModuleProxyService(t *testing.T) {
+	t.Parallel()
+
+	service := NewGoModuleProxyService(GoModuleProxyServiceOptions{})
+
+	if service.baseURL != goProxyBaseURL {
+		t.Errorf("baseURL = %q, want %q", service.baseURL, goProxyBaseURL)
+	}
+	if service.client != http.DefaultClient {
+		t.Error("client should be http.DefaultClient when not provided")
+	}
+}
+
+// TestEscapeModulePath tests the escapeModulePath function.
+func TestEscapeModulePath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "golang.org/x/text", want: "golang.org/x/text"},
+		{path: "github.com/BurntSushi/toml", want: "github.com/!burnt!sushi/toml"},
+		{path: "v1.0.0-RC1", want: "v1.0.0-!r!c1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			if got := escapeModulePath(tt.path); got != tt.want {
+				t.Errorf("escapeModulePath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCountGoModRequires tests the countGoModRequires function.
+func TestCountGoModRequires(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		goMod string
+		want  int
+	}{
+		{name: "no requirements", goMod: "module example.com/m\n\ngo 1.22\n", want: 0},
+		{name: "single and block requirements", goMod: goProxyTestMod, want: 1},
+		{
+			name:  "indirect marker with a reason",
+			goMod: "require (\n\tgithub.com/example/a v1.0.0\n\tgithub.com/example/b v1.0.0 // indirect; test only\n)\n",
+			want:  1,
+		},
+		{
+			name:  "other comments are direct",
+			goMod: "require github.com/example/a v1.0.0 // pinned for CVE-2024-0001\n",
+			want:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := countGoModRequires(tt.goMod); got != tt.want {
+				t.Errorf("countGoModRequires() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGoModuleProxyService_GetPackageInfo tests the GetPackageInfo method.
+func TestGoModuleProxyService_GetPackageInfo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		purl        string
+		wantVersion string
+		wantErr     error
+	}{
+		{
+			name:        "versioned purl",
+			purl:        "pkg:golang/github.com/BurntSushi/toml@v1.3.2",
+			wantVersion: "v1.3.2",
+		},
+		{
+			name:        "latest version",
+			purl:        "pkg:golang/github.com/BurntSushi/toml",
+			wantVersion: "v1.4.0",
+		},
+		{
+			name:    "unknown version",
+			purl:    "pkg:golang/github.com/BurntSushi/toml@v0.0.1",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name:    "unsupported ecosystem",
+			purl:    "pkg:npm/lodash@4.17.21",
+			wantErr: ErrUnsupportedEcosystem,
+		},
+	}
+
+	// packageurl lowercases golang namespaces and names, so no path escaping is expected here.
+	mux := http.NewServeMux()
+	mux.HandleFunc("/github.com/burntsushi/toml/@latest", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"Version":"v1.4.0","Time":"2024-06-12T10:00:00Z"}`))
+	})
+	mux.HandleFunc("/github.com/burntsushi/toml/@v/v1.3.2.info", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"Version":"v1.3.2","Time":"2023-06-08T10:00:00Z"}`))
+	})
+	modHandler := func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(goProxyTestMod))
+	}
+	mux.HandleFunc("/github.com/burntsushi/toml/@v/v1.3.2.mod", modHandler)
+	mux.HandleFunc("/github.com/burntsushi/toml/@v/v1.4.0.mod", modHandler)
+	mux.HandleFunc("/github.com/burntsushi/toml/@v/v0.0.1.info", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	service := NewGoModuleProxyService(GoModuleProxyServiceOptions{
+		BaseURL: server.URL,
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.GetPackageInfo(context.Background(), purl)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetPackageInfo() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+
+			if got.Name != "github.com/burntsushi/toml" {
+				t.Errorf("GetPackageInfo() Name = %q, want %q", got.Name, "github.com/burntsushi/toml")
+			}
+			if got.Version != tt.wantVersion {
+				t.Errorf("GetPackageInfo() Version = %q, want %q", got.Version, tt.wantVersion)
+			}
+			if got.Ecosystem != "golang" {
+				t.Errorf("GetPackageInfo() Ecosystem = %q, want %q", got.Ecosystem, "golang")
+			}
+			if got.Licenses == nil || len(got.Licenses) != 0 {
+				t.Errorf("GetPackageInfo() Licenses = %#v, want empty slice", got.Licenses)
+			}
+			if got.DependencyCount == nil || *got.DependencyCount != 1 {
+				t.Errorf("GetPackageInfo() DependencyCount = %v, want 1", got.DependencyCount)
+			}
+		})
+	}
+}
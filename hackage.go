@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+const (
+	// hackageBaseURL is the base URL for the Hackage API.
+	//
+	// See https://hackage.haskell.org/api
+	hackageBaseURL = "https://hackage.haskell.org"
+	// maxCabalFileSize is the maximum size of a .cabal file read from Hackage.
+	maxCabalFileSize = 1 << 20
+	// cabalHeadRepository is the kind of the source-repository section of the development repository.
+	cabalHeadRepository = "head"
+	// cabalSPDXVersion is the first cabal-version whose license field is an SPDX license expression.
+	cabalSPDXVersion = "2.2"
+)
+
+// HackageService is the service for the Hackage API of Haskell packages.
+//
+// The metadata comes from the .cabal file of the package version, which is parsed minimally (see parseCabal).
+// Before cabal-version 2.2, the license is a Cabal license name such as BSD3 rather than an SPDX identifier,
+// so the package info is flagged with NonSPDXLicenses.
+type HackageService struct {
+	baseURL string
+	client  *http.Client
+}
+
+var _ Service = (*HackageService)(nil)
+
+// HackageServiceOptions are the options for the HackageService.
+type HackageServiceOptions struct {
+	// BaseURL is the base URL for the Hackage API.
+	// If empty, defaults to hackage.haskell.org.
+	BaseURL string
+	// Client is the HTTP client to use for the Hackage API.
+	// If nil, defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// NewHackageService creates a new HackageService.
+func NewHackageService(opts HackageServiceOptions) *HackageService {
+	// Default to the Hackage API base URL.
+	baseURL := hackageBaseURL
+	if opts.BaseURL != "" {
+		baseURL = opts.BaseURL
+	}
+	// Default to the default HTTP client.
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &HackageService{
+		baseURL: baseURL,
+		client:  client,
+	}
+}
+
+// hackagePreferredResponse is the response from the Hackage preferred versions endpoint.
+type hackagePreferredResponse struct {
+	NormalVersion []string `json:"normal-version"`
+}
+
+// cabalFile holds the fields of a .cabal file used for the package info.
+type cabalFile struct {
+	CabalVersion string
+	Name         string
+	Version      string
+	License      string
+	Synopsis     string
+	Homepage     string
+	BugReports   string
+	// SourceRepository is the location of the head source-repository, or of the first one if there is
+	// no head repository.
+	SourceRepository string
+}
+
+// GetPackageInfo returns the information about a package.
+//
+// If the purl has no version, the newest preferred (non-deprecated) version is used.
+func (s *HackageService) GetPackageInfo(ctx context.Context, purl packageurl.PackageURL) (PackageInfo, error) {
+	if purl.Type != packageurl.TypeHackage {
+		return PackageInfo{}, fmt.Errorf("%w: %s", ErrUnsupportedEcosystem, purl.Type)
+	}
+
+	version := purl.Version
+	if version == "" {
+		var err error
+		if version, err = s.getPreferredVersion(ctx, purl.Name); err != nil {
+			return PackageInfo{}, err
+		}
+	}
+
+	packageID := url.PathEscape(purl.Name + "-" + version)
+	cabal, err := s.getCabalFile(ctx, fmt.Sprintf("%s/package/%s/%s.cabal",
+		s.baseURL, packageID, url.PathEscape(purl.Name)))
+	if err != nil {
+		return PackageInfo{}, err
+	}
+	file := parseCabal(cabal)
+
+	packageInfo := PackageInfo{
+		Name:          file.Name,
+		Version:       file.Version,
+		Licenses:      []string{},
+		Homepage:      file.Homepage,
+		RepositoryURL: file.SourceRepository,
+		Description:   file.Synopsis,
+		Ecosystem:     purl.Type,
+		BugTrackerURL: file.BugReports,
+	}
+	if packageInfo.Name == "" {
+		packageInfo.Name = purl.Name
+	}
+	if packageInfo.Version == "" {
+		packageInfo.Version = version
+	}
+	if file.License != "" {
+		packageInfo.Licenses = []string{file.License}
+		packageInfo.NonSPDXLicenses = !cabalSPDXLicense(file.CabalVersion)
+	}
+
+	return packageInfo, nil
+}
+
+// cabalSPDXLicense reports whether the license field of a .cabal file with the given cabal-version
+// is an SPDX license expression.
+//
+// Since cabal-version 2.2, the cabal-version is a bare version; the older files use a version range
+// such as ">=1.10", or have no cabal-version at all.
+func cabalSPDXLicense(cabalVersion string) bool {
+	if cabalVersion == "" || cabalVersion[0] < '0' || cabalVersion[0] > '9' {
+		return false
+	}
+	return compareVersions(cabalVersion, cabalSPDXVersion) >= 0
+}
+
+// getPreferredVersion returns the newest preferred version of a package.
+func (s *HackageService) getPreferredVersion(ctx context.Context, name string) (string, error) {
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+
+	var preferred hackagePreferredResponse
+	preferredURL := fmt.Sprintf("%s/package/%s/preferred", s.baseURL, url.PathEscape(name))
+	if err := getJSON(ctx, s.client, preferredURL, header, &preferred); err != nil {
+		return "", err
+	}
+	if len(preferred.NormalVersion) == 0 {
+		return "", fmt.Errorf("%w: %s has no preferred versions", ErrPackageNotFound, name)
+	}
+
+	versions := slices.Clone(preferred.NormalVersion)
+	sortVersions(versions)
+	return versions[len(versions)-1], nil
+}
+
+// getCabalFile returns the .cabal file at cabalURL.
+func (s *HackageService) getCabalFile(ctx context.Context, cabalURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cabalURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent())
+
+	response, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to make HTTP request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", statusError(response.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxCabalFileSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the .cabal file: %w", err)
+	}
+
+	return string(body), nil
+}
+
+// parseCabal parses the fields of a .cabal file used for the package info.
+//
+// This is not a full .cabal parser: it reads the top-level `field: value` lines (with their indented
+// continuation lines) and the location of the source-repository sections, and ignores the other
+// sections, conditionals and braces.
+func parseCabal(cabal string) cabalFile {
+	top := map[string]string{}
+	repositories := map[string]string{}
+	var firstRepository, section, field string
+
+	for line := range strings.Lines(cabal) {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		key, value, isField := cutCabalField(trimmed)
+		indented := line[0] == ' ' || line[0] == '\t'
+
+		switch {
+		case !indented && isField:
+			// A top-level field
+			section, field = "", key
+			top[key] = value
+		case !indented:
+			// A section header, such as "library" or "source-repository head"
+			section, field = strings.ToLower(strings.Join(strings.Fields(trimmed), " ")), ""
+		case section == "" && field != "":
+			// A continuation line of a top-level field, where "." stands for an empty line
+			if trimmed != "." {
+				top[field] = strings.TrimSpace(top[field] + " " + trimmed)
+			}
+		case isField && key == "location" && strings.HasPrefix(section, "source-repository"):
+			kind := strings.TrimSpace(strings.TrimPrefix(section, "source-repository"))
+			repositories[kind] = value
+			if firstRepository == "" {
+				firstRepository = value
+			}
+		}
+	}
+
+	repository := repositories[cabalHeadRepository]
+	if repository == "" {
+		repository = firstRepository
+	}
+
+	return cabalFile{
+		CabalVersion:     top["cabal-version"],
+		Name:             top["name"],
+		Version:          top["version"],
+		License:          top["license"],
+		Synopsis:         top["synopsis"],
+		Homepage:         top["homepage"],
+		BugReports:       top["bug-reports"],
+		SourceRepository: repository,
+	}
+}
+
+// cutCabalField splits a `field: value` line of a .cabal file into the lowercase field name and
+// the value, or returns false if the line is not a field.
+func cutCabalField(line string) (string, string, bool) {
+	key, value, found := strings.Cut(line, ":")
+	if !found || key == "" {
+		return "", "", false
+	}
+	for _, r := range key {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return "", "", false
+		}
+	}
+	return strings.ToLower(key), strings.TrimSpace(value), true
+}
@@ -0,0 +1,330 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// TestNewHackageService tests the NewHackageService function.
+func TestNewHackageService(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default options", func(t *testing.T) {
+		t.Parallel()
+
+		service := NewHackageService(HackageServiceOptions{})
+
+		if service.baseURL != hackageBaseURL {
+			t.Errorf("baseURL = %q, want %q", service.baseURL, hackageBaseURL)
+		}
+		if service.client != http.DefaultClient {
+			t.Error("client should be http.DefaultClient when not provided")
+		}
+	})
+
+	t.Run("custom base URL", func(t *testing.T) {
+		t.Parallel()
+
+		customURL := "https://hackage.example.com"
+		service := NewHackageService(HackageServiceOptions{
+			BaseURL: customURL,
+		})
+
+		if service.baseURL != customURL {
+			t.Errorf("baseURL = %q, want %q", service.baseURL, customURL)
+		}
+	})
+}
+
+// TestHackageService_GetPackageInfo tests the GetPackageInfo method.
+func TestHackageService_GetPackageInfo(t *testing.T) {
+	t.Parallel()
+
+	const aesonCabal = `cabal-version: 2.2
+name:          aeson
+version:       2.2.3.0
+license:       BSD-3-Clause
+synopsis:      Fast JSON parsing and encoding
+homepage:      https://github.com/haskell/aeson
+bug-reports:   https://github.com/haskell/aeson/issues
+
+library
+  default-language: Haskell2010
+  build-depends:    base >=4.10 && <5
+
+source-repository head
+  type:     git
+  location: https://github.com/haskell/aeson.git
+`
+
+	// mockResponse is the status code and body of the response to a path.
+	type mockResponse struct {
+		statusCode int
+		body       string
+	}
+
+	tests := []struct {
+		name      string
+		responses map[string]mockResponse
+		purl      string
+		want      PackageInfo
+		wantErr   error
+	}{
+		{
+			name: "package with version",
+			responses: map[string]mockResponse{
+				"/package/aeson-2.2.3.0/aeson.cabal": {http.StatusOK, aesonCabal},
+			},
+			purl: "pkg:hackage/aeson@2.2.3.0",
+			want: PackageInfo{
+				Name:          "aeson",
+				Version:       "2.2.3.0",
+				Licenses:      []string{"BSD-3-Clause"},
+				Homepage:      "https://github.com/haskell/aeson",
+				RepositoryURL: "https://github.com/haskell/aeson.git",
+				Description:   "Fast JSON parsing and encoding",
+				Ecosystem:     "hackage",
+				BugTrackerURL: "https://github.com/haskell/aeson/issues",
+			},
+		},
+		{
+			name: "package without version uses newest preferred version",
+			responses: map[string]mockResponse{
+				"/package/aeson/preferred": {http.StatusOK, `{
+					"normal-version": ["2.2.3.0", "2.10.0.0", "2.2.2.0"],
+					"deprecated-version": ["3.0.0.0"]
+				}`},
+				"/package/aeson-2.10.0.0/aeson.cabal": {http.StatusOK, "name: aeson\nversion: 2.10.0.0\n"},
+			},
+			purl: "pkg:hackage/aeson",
+			want: PackageInfo{
+				Name:      "aeson",
+				Version:   "2.10.0.0",
+				Licenses:  []string{},
+				Ecosystem: "hackage",
+			},
+		},
+		{
+			name: "Cabal license name before cabal-version 2.2",
+			responses: map[string]mockResponse{
+				"/package/network-3.1.4.0/network.cabal": {http.StatusOK, "cabal-version: >=1.10\n" +
+					"name: network\nversion: 3.1.4.0\nlicense: BSD3\n"},
+			},
+			purl: "pkg:hackage/network@3.1.4.0",
+			want: PackageInfo{
+				Name:            "network",
+				Version:         "3.1.4.0",
+				Licenses:        []string{"BSD3"},
+				NonSPDXLicenses: true,
+				Ecosystem:       "hackage",
+			},
+		},
+		{
+			name: "package without preferred versions",
+			responses: map[string]mockResponse{
+				"/package/old/preferred": {http.StatusOK, `{"normal-version": [], "deprecated-version": ["0.1"]}`},
+			},
+			purl:    "pkg:hackage/old",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name: "missing version",
+			responses: map[string]mockResponse{
+				"/package/aeson-0.0.1/aeson.cabal": {http.StatusNotFound, "Package not found"},
+			},
+			purl:    "pkg:hackage/aeson@0.0.1",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name: "malformed preferred versions",
+			responses: map[string]mockResponse{
+				"/package/aeson/preferred": {http.StatusOK, `<html></html>`},
+			},
+			purl:    "pkg:hackage/aeson",
+			wantErr: ErrInvalidResponse,
+		},
+		{
+			name:    "unsupported ecosystem",
+			purl:    "pkg:cran/ggplot2@3.5.1",
+			wantErr: ErrUnsupportedEcosystem,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				response, ok := tt.responses[r.URL.Path]
+				if !ok {
+					t.Errorf("unexpected request path %q", r.URL.Path)
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(response.statusCode)
+				_, _ = w.Write([]byte(response.body))
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewHackageService(HackageServiceOptions{
+				BaseURL: server.URL,
+			})
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.GetPackageInfo(context.Background(), purl)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetPackageInfo() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+
+			if got.Name != tt.want.Name {
+				t.Errorf("GetPackageInfo() Name = %q, want %q", got.Name, tt.want.Name)
+			}
+			if got.Version != tt.want.Version {
+				t.Errorf("GetPackageInfo() Version = %q, want %q", got.Version, tt.want.Version)
+			}
+			if got.Ecosystem != tt.want.Ecosystem {
+				t.Errorf("GetPackageInfo() Ecosystem = %q, want %q", got.Ecosystem, tt.want.Ecosystem)
+			}
+			if !equalStringSlices(got.Licenses, tt.want.Licenses) {
+				t.Errorf("GetPackageInfo() Licenses = %v, want %v", got.Licenses, tt.want.Licenses)
+			}
+			if got.NonSPDXLicenses != tt.want.NonSPDXLicenses {
+				t.Errorf("GetPackageInfo() NonSPDXLicenses = %v, want %v", got.NonSPDXLicenses, tt.want.NonSPDXLicenses)
+			}
+			if got.Homepage != tt.want.Homepage {
+				t.Errorf("GetPackageInfo() Homepage = %q, want %q", got.Homepage, tt.want.Homepage)
+			}
+			if got.RepositoryURL != tt.want.RepositoryURL {
+				t.Errorf("GetPackageInfo() RepositoryURL = %q, want %q", got.RepositoryURL, tt.want.RepositoryURL)
+			}
+			if got.Description != tt.want.Description {
+				t.Errorf("GetPackageInfo() Description = %q, want %q", got.Description, tt.want.Description)
+			}
+			if got.BugTrackerURL != tt.want.BugTrackerURL {
+				t.Errorf("GetPackageInfo() BugTrackerURL = %q, want %q", got.BugTrackerURL, tt.want.BugTrackerURL)
+			}
+		})
+	}
+}
+
+// TestParseCabal tests the parseCabal function.
+func TestParseCabal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		cabal string
+		want  cabalFile
+	}{
+		{
+			name:  "empty file",
+			cabal: "",
+			want:  cabalFile{},
+		},
+		{
+			name: "field names are case-insensitive",
+			cabal: "Cabal-Version: 3.0\n" +
+				"Name: text\n" +
+				"Version: 2.1.1\n" +
+				"License: BSD-2-Clause\n",
+			want: cabalFile{CabalVersion: "3.0", Name: "text", Version: "2.1.1", License: "BSD-2-Clause"},
+		},
+		{
+			name: "continuation lines and comments",
+			cabal: "-- A comment\n" +
+				"name: lens\n" +
+				"synopsis:\n" +
+				"  Lenses, Folds\n" +
+				"  and Traversals\n" +
+				"description:\n" +
+				"  A long description.\n" +
+				"  .\n" +
+				"  Second paragraph.\n",
+			want: cabalFile{Name: "lens", Synopsis: "Lenses, Folds and Traversals"},
+		},
+		{
+			name: "section fields are not top-level fields",
+			cabal: "name: tool\n" +
+				"executable tool\n" +
+				"  main-is: Main.hs\n" +
+				"  homepage: https://ignored.example.com\n",
+			want: cabalFile{Name: "tool"},
+		},
+		{
+			name: "head repository is preferred",
+			cabal: "name: pkg\n" +
+				"source-repository this\n" +
+				"  type: git\n" +
+				"  location: https://example.com/pkg-this.git\n" +
+				"  tag: v1.0\n" +
+				"Source-Repository head\n" +
+				"  type: git\n" +
+				"  location: https://example.com/pkg.git\n",
+			want: cabalFile{Name: "pkg", SourceRepository: "https://example.com/pkg.git"},
+		},
+		{
+			name: "first repository without head",
+			cabal: "source-repository this\n" +
+				"  location: https://example.com/pkg-this.git\n",
+			want: cabalFile{SourceRepository: "https://example.com/pkg-this.git"},
+		},
+		{
+			name:  "URLs keep their colons",
+			cabal: "homepage: https://haskell.org\nbug-reports: https://example.com/issues\n",
+			want:  cabalFile{Homepage: "https://haskell.org", BugReports: "https://example.com/issues"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := parseCabal(tt.cabal); got != tt.want {
+				t.Errorf("parseCabal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCabalSPDXLicense tests the cabalSPDXLicense function.
+func TestCabalSPDXLicense(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		cabalVersion string
+		want         bool
+	}{
+		{cabalVersion: "", want: false},
+		{cabalVersion: ">=1.10", want: false},
+		{cabalVersion: "1.24", want: false},
+		{cabalVersion: "2.0", want: false},
+		{cabalVersion: "2.2", want: true},
+		{cabalVersion: "3.0", want: true},
+		{cabalVersion: "3.12", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cabalVersion, func(t *testing.T) {
+			t.Parallel()
+
+			if got := cabalSPDXLicense(tt.cabalVersion); got != tt.want {
+				t.Errorf("cabalSPDXLicense(%q) = %v, want %v", tt.cabalVersion, got, tt.want)
+			}
+		})
+	}
+}
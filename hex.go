@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+const (
+	// hexBaseURL is the base URL for the Hex.pm API.
+	//
+	// See https://github.com/hexpm/specifications/blob/main/apiary.apib
+	hexBaseURL = "https://hex.pm"
+	// hexPackagesAPIPath is the API path for package information.
+	hexPackagesAPIPath = "/api/packages"
+	// hexPublicRepository is the name of the public Hex.pm repository.
+	hexPublicRepository = "hexpm"
+)
+
+// HexService is the service for the Hex.pm API of Elixir and Erlang packages.
+//
+// Only the public repository is supported: packages of organization repositories (a purl namespace) are private.
+type HexService struct {
+	baseURL string
+	client  *http.Client
+}
+
+var _ Service = (*HexService)(nil)
+
+// HexServiceOptions are the options for the HexService.
+type HexServiceOptions struct {
+	// BaseURL is the base URL for the Hex.pm API.
+	// If empty, defaults to hex.pm.
+	BaseURL string
+	// Client is the HTTP client to use for the Hex.pm API.
+	// If nil, defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// NewHexService creates a new HexService.
+func NewHexService(opts HexServiceOptions) *HexService {
+	// Default to the Hex.pm API base URL.
+	baseURL := hexBaseURL
+	if opts.BaseURL != "" {
+		baseURL = opts.BaseURL
+	}
+	// Default to the default HTTP client.
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &HexService{
+		baseURL: baseURL,
+		client:  client,
+	}
+}
+
+// hexPackageResponse is the response from the Hex.pm package endpoint.
+type hexPackageResponse struct {
+	Name                string  `json:"name"`
+	LatestStableVersion *string `json:"latest_stable_version"`
+	LatestVersion       *string `json:"latest_version"`
+	DocsHTMLURL         *string `json:"docs_html_url"`
+	Meta                struct {
+		Description *string           `json:"description"`
+		Licenses    []string          `json:"licenses"`
+		Links       map[string]string `json:"links"`
+	} `json:"meta"`
+	Releases []hexRelease `json:"releases"`
+}
+
+// hexRelease is a release of a package in the response from the Hex.pm package endpoint.
+type hexRelease struct {
+	Version string `json:"version"`
+}
+
+// GetPackageInfo returns the information about a package.
+//
+// The metadata of a package is that of its latest release: Hex.pm does not serve the licenses
+// and links of older releases.
+func (s *HexService) GetPackageInfo(ctx context.Context, purl packageurl.PackageURL) (PackageInfo, error) {
+	if purl.Type != packageurl.TypeHex {
+		return PackageInfo{}, fmt.Errorf("%w: %s", ErrUnsupportedEcosystem, purl.Type)
+	}
+
+	// The namespace of private packages is their organization repository
+	if purl.Namespace != "" && purl.Namespace != hexPublicRepository {
+		return PackageInfo{}, fmt.Errorf("%w: hex repository %s", ErrUnsupportedEcosystem, purl.Namespace)
+	}
+
+	packageURL := fmt.Sprintf("%s%s/%s", s.baseURL, hexPackagesAPIPath, url.PathEscape(purl.Name))
+
+	var pkg hexPackageResponse
+	if err := getJSON(ctx, s.client, packageURL, nil, &pkg); err != nil {
+		return PackageInfo{}, err
+	}
+
+	// Packages with only pre-releases have no stable version
+	version := stringValue(pkg.LatestStableVersion)
+	if version == "" {
+		version = stringValue(pkg.LatestVersion)
+	}
+	if purl.Version != "" {
+		released := slices.ContainsFunc(pkg.Releases, func(r hexRelease) bool { return r.Version == purl.Version })
+		if !released {
+			return PackageInfo{}, fmt.Errorf("%w: %s version %s", ErrPackageNotFound, purl.Name, purl.Version)
+		}
+		version = purl.Version
+	}
+
+	documentationURL := hexLink(pkg.Meta.Links, "Docs", "Documentation")
+	if documentationURL == "" {
+		documentationURL = stringValue(pkg.DocsHTMLURL)
+	}
+
+	licenses := pkg.Meta.Licenses
+	if licenses == nil {
+		licenses = []string{}
+	}
+
+	return PackageInfo{
+		Name:             pkg.Name,
+		Version:          version,
+		Licenses:         licenses,
+		Homepage:         hexLink(pkg.Meta.Links, "Website", "Homepage"),
+		RepositoryURL:    hexLink(pkg.Meta.Links, "GitHub", "GitLab", "Source", "Repository"),
+		Description:      stringValue(pkg.Meta.Description),
+		Ecosystem:        purl.Type,
+		DocumentationURL: documentationURL,
+		ChangelogURL:     hexLink(pkg.Meta.Links, "Changelog"),
+	}, nil
+}
+
+// hexLink returns the first of the links of a package with one of the names, or an empty string.
+//
+// The link names are free-form, so they are compared case-insensitively (e.g., both GitHub and Github
+// are common).
+func hexLink(links map[string]string, names ...string) string {
+	for _, name := range names {
+		for label, link := range links {
+			if strings.EqualFold(label, name) {
+				return link
+			}
+		}
+	}
+	return ""
+}
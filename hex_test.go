@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// TestNewHexService tests the NewHexService function.
+func TestNewHexService(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default options", func(t *testing.T) {
+		t.Parallel()
+
+		service := NewHexService(HexServiceOptions{})
+
+		if service.baseURL != hexBaseURL {
+			t.Errorf("baseURL = %q, want %q", service.baseURL, hexBaseURL)
+		}
+		if service.client != http.DefaultClient {
+			t.Error("client should be http.DefaultClient when not provided")
+		}
+	})
+
+	t.Run("custom base URL", func(t *testing.T) {
+		t.Parallel()
+
+		customURL := "https://hex.example.com"
+		service := NewHexService(HexServiceOptions{
+			BaseURL: customURL,
+		})
+
+		if service.baseURL != customURL {
+			t.Errorf("baseURL = %q, want %q", service.baseURL, customURL)
+		}
+	})
+}
+
+// TestHexService_GetPackageInfo tests the GetPackageInfo method.
+func TestHexService_GetPackageInfo(t *testing.T) {
+	t.Parallel()
+
+	const phoenixPackage = `{
+		"name": "phoenix",
+		"latest_stable_version": "1.7.14",
+		"latest_version": "1.8.0-rc.0",
+		"docs_html_url": "https://hexdocs.pm/phoenix/",
+		"meta": {
+			"description": "Peace of mind from prototype to production",
+			"licenses": ["MIT"],
+			"links": {"Github": "https://github.com/phoenixframework/phoenix", "Website": "https://www.phoenixframework.org"}
+		},
+		"releases": [{"version": "1.8.0-rc.0"}, {"version": "1.7.14"}, {"version": "1.6.16"}]
+	}`
+
+	tests := []struct {
+		name           string
+		mockResponse   string
+		mockStatusCode int
+		purl           string
+		wantPath       string
+		want           PackageInfo
+		wantErr        error
+	}{
+		{
+			name:           "package without version",
+			mockResponse:   phoenixPackage,
+			mockStatusCode: http.StatusOK,
+			purl:           "pkg:hex/phoenix",
+			wantPath:       "/api/packages/phoenix",
+			want: PackageInfo{
+				Name:             "phoenix",
+				Version:          "1.7.14",
+				Licenses:         []string{"MIT"},
+				Homepage:         "https://www.phoenixframework.org",
+				RepositoryURL:    "https://github.com/phoenixframework/phoenix",
+				Description:      "Peace of mind from prototype to production",
+				Ecosystem:        "hex",
+				DocumentationURL: "https://hexdocs.pm/phoenix/",
+			},
+		},
+		{
+			name:           "older version",
+			mockResponse:   phoenixPackage,
+			mockStatusCode: http.StatusOK,
+			purl:           "pkg:hex/hexpm/phoenix@1.6.16",
+			wantPath:       "/api/packages/phoenix",
+			want: PackageInfo{
+				Name:             "phoenix",
+				Version:          "1.6.16",
+				Licenses:         []string{"MIT"},
+				Homepage:         "https://www.phoenixframework.org",
+				RepositoryURL:    "https://github.com/phoenixframework/phoenix",
+				Description:      "Peace of mind from prototype to production",
+				Ecosystem:        "hex",
+				DocumentationURL: "https://hexdocs.pm/phoenix/",
+			},
+		},
+		{
+			name: "pre-release only with docs link",
+			mockResponse: `{
+				"name": "early",
+				"latest_stable_version": null,
+				"latest_version": "0.1.0-dev",
+				"meta": {"links": {"Docs": "https://example.com/docs"}},
+				"releases": [{"version": "0.1.0-dev"}]
+			}`,
+			mockStatusCode: http.StatusOK,
+			purl:           "pkg:hex/early",
+			wantPath:       "/api/packages/early",
+			want: PackageInfo{
+				Name:             "early",
+				Version:          "0.1.0-dev",
+				Licenses:         []string{},
+				Ecosystem:        "hex",
+				DocumentationURL: "https://example.com/docs",
+			},
+		},
+		{
+			name:           "missing version",
+			mockResponse:   phoenixPackage,
+			mockStatusCode: http.StatusOK,
+			purl:           "pkg:hex/phoenix@0.0.1",
+			wantPath:       "/api/packages/phoenix",
+			wantErr:        ErrPackageNotFound,
+		},
+		{
+			name:           "HTTP 404 error",
+			mockResponse:   `{"status": 404, "message": "Page not found"}`,
+			mockStatusCode: http.StatusNotFound,
+			purl:           "pkg:hex/missing",
+			wantPath:       "/api/packages/missing",
+			wantErr:        ErrPackageNotFound,
+		},
+		{
+			name:           "malformed JSON",
+			mockResponse:   `{invalid json}`,
+			mockStatusCode: http.StatusOK,
+			purl:           "pkg:hex/phoenix",
+			wantPath:       "/api/packages/phoenix",
+			wantErr:        ErrInvalidResponse,
+		},
+		{
+			name:    "organization repository",
+			purl:    "pkg:hex/acme/foo@1.0.0",
+			wantErr: ErrUnsupportedEcosystem,
+		},
+		{
+			name:    "unsupported ecosystem",
+			purl:    "pkg:gem/rails@7.1.3",
+			wantErr: ErrUnsupportedEcosystem,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.wantPath {
+					t.Errorf("request path = %q, want %q", r.URL.Path, tt.wantPath)
+				}
+
+				w.WriteHeader(tt.mockStatusCode)
+				_, _ = w.Write([]byte(tt.mockResponse))
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewHexService(HexServiceOptions{
+				BaseURL: server.URL,
+			})
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.GetPackageInfo(context.Background(), purl)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetPackageInfo() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+
+			if got.Name != tt.want.Name {
+				t.Errorf("GetPackageInfo() Name = %q, want %q", got.Name, tt.want.Name)
+			}
+			if got.Version != tt.want.Version {
+				t.Errorf("GetPackageInfo() Version = %q, want %q", got.Version, tt.want.Version)
+			}
+			if got.Ecosystem != tt.want.Ecosystem {
+				t.Errorf("GetPackageInfo() Ecosystem = %q, want %q", got.Ecosystem, tt.want.Ecosystem)
+			}
+			if !equalStringSlices(got.Licenses, tt.want.Licenses) {
+				t.Errorf("GetPackageInfo() Licenses = %v, want %v", got.Licenses, tt.want.Licenses)
+			}
+			if got.Homepage != tt.want.Homepage {
+				t.Errorf("GetPackageInfo() Homepage = %q, want %q", got.Homepage, tt.want.Homepage)
+			}
+			if got.RepositoryURL != tt.want.RepositoryURL {
+				t.Errorf("GetPackageInfo() RepositoryURL = %q, want %q", got.RepositoryURL, tt.want.RepositoryURL)
+			}
+			if got.Description != tt.want.Description {
+				t.Errorf("GetPackageInfo() Description = %q, want %q", got.Description, tt.want.Description)
+			}
+			if got.DocumentationURL != tt.want.DocumentationURL {
+				t.Errorf("GetPackageInfo() DocumentationURL = %q, want %q", got.DocumentationURL, tt.want.DocumentationURL)
+			}
+		})
+	}
+}
+
+// TestHexLink tests the hexLink function.
+func TestHexLink(t *testing.T) {
+	t.Parallel()
+
+	links := map[string]string{
+		"GitHub":    "https://github.com/elixir-lang/gettext",
+		"changelog": "https://hexdocs.pm/gettext/changelog.html",
+	}
+
+	tests := []struct {
+		name  string
+		names []string
+		want  string
+	}{
+		{name: "exact name", names: []string{"GitHub"}, want: "https://github.com/elixir-lang/gettext"},
+		{name: "other case", names: []string{"Changelog"}, want: "https://hexdocs.pm/gettext/changelog.html"},
+		{name: "first matching name", names: []string{"Source", "Github"}, want: "https://github.com/elixir-lang/gettext"},
+		{name: "no match", names: []string{"Website"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := hexLink(links, tt.names...); got != tt.want {
+				t.Errorf("hexLink(%v) = %q, want %q", tt.names, got, tt.want)
+			}
+		})
+	}
+}
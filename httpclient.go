@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// projectURL is the purlinfo project URL, advertised in the User-Agent header.
+const projectURL = "https://github.com/boringbin/purlinfo"
+
+// userAgent returns the default User-Agent header value sent by purlinfo.
+func userAgent() string {
+	return "purlinfo/" + version + " (+" + projectURL + ")"
+}
+
+// StatusError is the error for a non-200 HTTP status code of an API response.
+//
+// It wraps ErrPackageNotFound, ErrRateLimited or ErrAPIError depending on the status code (see statusError).
+type StatusError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// err is the sentinel error wrapped by the error.
+	err error
+}
+
+// Error returns the sentinel error message followed by the status code.
+func (e *StatusError) Error() string {
+	if isServiceUnavailable(e.StatusCode) {
+		return fmt.Sprintf("%v: service unavailable: HTTP %d", e.err, e.StatusCode)
+	}
+	return fmt.Sprintf("%v: HTTP %d", e.err, e.StatusCode)
+}
+
+// Unwrap returns the sentinel error wrapped by the error.
+func (e *StatusError) Unwrap() error {
+	return e.err
+}
+
+// statusError converts a non-200 HTTP status code into a *StatusError.
+func statusError(statusCode int) error {
+	switch statusCode {
+	case http.StatusNotFound, http.StatusGone:
+		return &StatusError{StatusCode: statusCode, err: ErrPackageNotFound}
+	case http.StatusTooManyRequests:
+		return &StatusError{StatusCode: statusCode, err: ErrRateLimited}
+	default:
+		return &StatusError{StatusCode: statusCode, err: ErrAPIError}
+	}
+}
+
+// isServiceUnavailable reports whether the status code is a transient gateway or availability error.
+func isServiceUnavailable(statusCode int) bool {
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// getJSON makes a GET request to apiURL and decodes the JSON response body into v.
+//
+// The headers are added to the request in addition to the User-Agent header.
+func getJSON(ctx context.Context, client *http.Client, apiURL string, header http.Header, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", userAgent())
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	response, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make HTTP request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return statusError(response.StatusCode)
+	}
+
+	if decodeErr := json.NewDecoder(response.Body).Decode(v); decodeErr != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidResponse, decodeErr)
+	}
+
+	return nil
+}
+
+// nextPageURL returns the URL of the "next" link of a Link header (RFC 8288), or an empty string.
+//
+// Relative URLs are resolved against the request URL.
+func nextPageURL(header http.Header, requestURL *url.URL) string {
+	for _, value := range header.Values("Link") {
+		for link := range strings.SplitSeq(value, ",") {
+			target, params, found := strings.Cut(strings.TrimSpace(link), ";")
+			if !found || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			if !hasNextRel(params) {
+				continue
+			}
+
+			next, err := requestURL.Parse(strings.Trim(target, "<>"))
+			if err != nil {
+				return ""
+			}
+			return next.String()
+		}
+	}
+	return ""
+}
+
+// hasNextRel reports whether the parameters of a Link header entry include rel="next".
+func hasNextRel(params string) bool {
+	for param := range strings.SplitSeq(params, ";") {
+		name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		if !strings.EqualFold(name, "rel") {
+			continue
+		}
+		// The rel parameter may hold several space-separated relation types
+		for rel := range strings.FieldsSeq(strings.Trim(value, `"`)) {
+			if strings.EqualFold(rel, "next") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header value, which is either a number of seconds
+// or an HTTP date, into the duration to wait from now.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	// A date in the past means the request can be retried immediately.
+	return max(date.Sub(now), 0), true
+}
+
+// rateLimitResetEpochThreshold is the smallest X-RateLimit-Reset value read as a Unix time rather than
+// as a number of seconds from now (2001-09-09, far longer than any rate limit window).
+const rateLimitResetEpochThreshold = 1_000_000_000
+
+// parseRateLimitReset parses an X-RateLimit-Reset header value into the time the rate limit budget is reset.
+//
+// APIs send either a Unix time in seconds (e.g., GitHub) or a number of seconds from now (e.g., the IETF
+// RateLimit header fields), told apart by magnitude.
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < 0 {
+		return time.Time{}, false
+	}
+	if seconds >= rateLimitResetEpochThreshold {
+		return time.Unix(seconds, 0), true
+	}
+	return now.Add(time.Duration(seconds) * time.Second), true
+}
+
+// sleepContext waits for the duration or until the context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("interrupted while waiting to retry: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
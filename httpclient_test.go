@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// TestStatusError tests the statusError function.
+func TestStatusError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		statusCode  int
+		wantErr     error
+		wantMessage string
+	}{
+		{statusCode: http.StatusNotFound, wantErr: ErrPackageNotFound, wantMessage: "package not found: HTTP 404"},
+		{statusCode: http.StatusGone, wantErr: ErrPackageNotFound, wantMessage: "package not found: HTTP 410"},
+		{statusCode: http.StatusTooManyRequests, wantErr: ErrRateLimited, wantMessage: "rate limited by API: HTTP 429"},
+		{statusCode: http.StatusUnprocessableEntity, wantErr: ErrAPIError, wantMessage: "API error: HTTP 422"},
+		{
+			statusCode:  http.StatusServiceUnavailable,
+			wantErr:     ErrAPIError,
+			wantMessage: "API error: service unavailable: HTTP 503",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.statusCode), func(t *testing.T) {
+			t.Parallel()
+
+			err := statusError(tt.statusCode)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("statusError(%d) = %v, want %v", tt.statusCode, err, tt.wantErr)
+			}
+			if err.Error() != tt.wantMessage {
+				t.Errorf("statusError(%d) message = %q, want %q", tt.statusCode, err.Error(), tt.wantMessage)
+			}
+			var statusErr *StatusError
+			if !errors.As(err, &statusErr) || statusErr.StatusCode != tt.statusCode {
+				t.Errorf("statusError(%d) is not a *StatusError with the status code", tt.statusCode)
+			}
+		})
+	}
+}
+
+// TestNextPageURL tests the nextPageURL function.
+func TestNextPageURL(t *testing.T) {
+	t.Parallel()
+
+	requestURL, err := url.Parse("https://example.com/api/items?page=1")
+	if err != nil {
+		t.Fatalf("failed to parse URL: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		links []string
+		want  string
+	}{
+		{name: "no Link header", links: nil, want: ""},
+		{
+			name:  "absolute next link",
+			links: []string{`<https://example.com/api/items?page=2>; rel="next"`},
+			want:  "https://example.com/api/items?page=2",
+		},
+		{
+			name:  "relative next link",
+			links: []string{`</api/items?page=2>; rel="next"`},
+			want:  "https://example.com/api/items?page=2",
+		},
+		{
+			name:  "several links",
+			links: []string{`</api/items?page=1>; rel="first", </api/items?page=3>; rel=next`},
+			want:  "https://example.com/api/items?page=3",
+		},
+		{
+			name:  "several Link headers",
+			links: []string{`</api/items?page=1>; rel="prev"`, `</api/items?page=2>; rel="next"`},
+			want:  "https://example.com/api/items?page=2",
+		},
+		{
+			name:  "several relation types",
+			links: []string{`</api/items?page=2>; rel="next last"`},
+			want:  "https://example.com/api/items?page=2",
+		},
+		{
+			name:  "no next link",
+			links: []string{`</api/items?page=1>; rel="prev"`},
+			want:  "",
+		},
+		{
+			name:  "malformed link",
+			links: []string{`/api/items?page=2; rel="next"`},
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			header := http.Header{}
+			for _, link := range tt.links {
+				header.Add("Link", link)
+			}
+
+			if got := nextPageURL(header, requestURL); got != tt.want {
+				t.Errorf("nextPageURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseRetryAfter tests the parseRetryAfter function.
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "empty", value: "", wantOK: false},
+		{name: "seconds", value: "120", want: 2 * time.Minute, wantOK: true},
+		{name: "zero seconds", value: "0", want: 0, wantOK: true},
+		{name: "negative seconds", value: "-1", wantOK: false},
+		{
+			name:   "HTTP date",
+			value:  now.Add(30 * time.Second).Format(http.TimeFormat),
+			want:   30 * time.Second,
+			wantOK: true,
+		},
+		{
+			name:   "HTTP date in the past",
+			value:  now.Add(-time.Minute).Format(http.TimeFormat),
+			want:   0,
+			wantOK: true,
+		},
+		{name: "invalid", value: "soon", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := parseRetryAfter(tt.value, now)
+			if ok != tt.wantOK {
+				t.Fatalf("parseRetryAfter(%q) ok = %v, want %v", tt.value, ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseRateLimitReset tests the parseRateLimitReset function.
+func TestParseRateLimitReset(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Time
+		wantOK bool
+	}{
+		{name: "empty", value: "", wantOK: false},
+		{name: "seconds from now", value: "60", want: now.Add(time.Minute), wantOK: true},
+		{name: "Unix time", value: strconv.FormatInt(now.Add(time.Hour).Unix(), 10), want: now.Add(time.Hour), wantOK: true},
+		{name: "negative", value: "-1", wantOK: false},
+		{name: "invalid", value: "soon", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := parseRateLimitReset(tt.value, now)
+			if ok != tt.wantOK {
+				t.Fatalf("parseRateLimitReset(%q) ok = %v, want %v", tt.value, ok, tt.wantOK)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseRateLimitReset(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSleepContext tests the sleepContext function.
+func TestSleepContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("completes", func(t *testing.T) {
+		t.Parallel()
+
+		if err := sleepContext(context.Background(), time.Millisecond); err != nil {
+			t.Errorf("sleepContext() unexpected error = %v", err)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := sleepContext(ctx, time.Hour); err == nil {
+			t.Error("sleepContext() with cancelled context should return error")
+		}
+	})
+}
@@ -2,16 +2,25 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log/slog"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/atotto/clipboard"
 	"github.com/package-url/packageurl-go"
 )
 
@@ -20,6 +29,15 @@ import (
 // Overridden by goreleaser via -ldflags "-X main.version=v0.1.0" when creating releases.
 var version = "dev"
 
+// Build metadata, set by goreleaser via -ldflags "-X main.buildCommit=abc1234 -X main.buildTime=...".
+// When empty, the VCS information embedded by the Go toolchain is used instead.
+var (
+	// buildCommit is the commit the CLI was built from.
+	buildCommit = "" //nolint:gochecknoglobals // set with -ldflags -X
+	// buildTime is the time the CLI was built, in RFC 3339 format.
+	buildTime = "" //nolint:gochecknoglobals // set with -ldflags -X
+)
+
 const (
 	// exitSuccess is the exit code for success.
 	exitSuccess = 0
@@ -27,10 +45,67 @@ const (
 	exitInvalidArgs = 1
 	// exitInvalidPurl is the exit code for invalid purl.
 	exitInvalidPurl = 2
-	// exitRuntimeError is the exit code for runtime error.
-	exitRuntimeError = 3
+	// exitNetworkError is the exit code for a request that failed to reach the API (or timed out).
+	exitNetworkError = 3
+	// exitAPIError is the exit code for an error response or an invalid response from the API.
+	exitAPIError = 4
+	// exitRateLimited is the exit code for a request rejected by the API because of rate limiting.
+	exitRateLimited = 5
+	// exitNotFound is the exit code for a package that was not found.
+	exitNotFound = 6
+	// exitRuntimeError is the exit code for an unexpected error.
+	// It skips 7, which was the exit code of -fail-on-not-found.
+	exitRuntimeError = 8
 	// defaultTimeoutSec is the default timeout in seconds.
 	defaultTimeoutSec = 30
+	// tokenEnvVar is the environment variable used when the -token flag is not set.
+	tokenEnvVar = "PURLINFO_TOKEN"
+	// formatText is the human-readable output format.
+	formatText = "text"
+	// formatJSON is the JSON output format.
+	formatJSON = "json"
+	// formatFingerprint is the output format printing only the fingerprint of the package info.
+	formatFingerprint = "fingerprint"
+	// formatTOML is the TOML output format.
+	formatTOML = "toml"
+	// logFormatText is the logfmt-style log format.
+	logFormatText = "text"
+	// logFormatJSON is the JSON log format.
+	logFormatJSON = "json"
+)
+
+// registryURLUsage is the usage message of the -registry-url flag.
+const registryURLUsage = "Base URL of a registry mirror for the backend (for nuget, the V3 service index URL)"
+
+const (
+	// backendEcosystems selects the Ecosyste.ms backend.
+	backendEcosystems = "ecosystems"
+	// backendGitHubPackages selects the GitHub Packages backend.
+	backendGitHubPackages = "github-packages"
+	// backendGitHubActions selects the GitHub Actions backend.
+	backendGitHubActions = "github-actions"
+	// backendRubyGems selects the RubyGems backend.
+	backendRubyGems = "rubygems"
+	// backendNuGet selects the NuGet backend.
+	backendNuGet = "nuget"
+	// backendMavenCentral selects the Maven Central backend.
+	backendMavenCentral = "maven-central"
+	// backendGoProxy selects the Go module proxy backend.
+	backendGoProxy = "goproxy"
+	// backendDockerHub selects the Docker Hub backend.
+	backendDockerHub = "dockerhub"
+	// backendHex selects the Hex.pm backend.
+	backendHex = "hex"
+	// backendPub selects the pub.dev backend.
+	backendPub = "pub"
+	// backendPackagist selects the Packagist backend.
+	backendPackagist = "packagist"
+	// backendCPAN selects the MetaCPAN backend.
+	backendCPAN = "cpan"
+	// backendCRAN selects the CRAN backend.
+	backendCRAN = "cran"
+	// backendHackage selects the Hackage backend.
+	backendHackage = "hackage"
 )
 
 func main() {
@@ -38,145 +113,875 @@ func main() {
 }
 
 func run() int {
-	var (
-		outputJSON  = flag.Bool("json", false, "Output as JSON")
-		verbose     = flag.Bool("v", false, "Verbose output (debug mode)")
-		showVersion = flag.Bool("version", false, "Show version and exit")
-		timeout     = flag.Duration("timeout", defaultTimeoutSec*time.Second, "HTTP request timeout")
-		email       = flag.String("email", "", "Email for polite pool (optional)")
-	)
+	var opts cliOptions
+	defineFlags(&opts)
 
 	// Customize usage message
 	printUsageFunc := func() {
-		printUsage()
+		printUsage(os.Stderr)
 	}
 	flag.CommandLine.Usage = printUsageFunc
 
 	flag.Parse()
 
 	// Handle version flag
-	if *showVersion {
-		fmt.Fprintf(os.Stdout, "purlinfo version %s\n", version)
+	if opts.showVersion {
+		if err := printVersion(os.Stdout, opts.outputJSON || opts.format == formatJSON); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitRuntimeError
+		}
 		return exitSuccess
 	}
 
-	// Setup logger based on verbose flag
-	logger := setupLogger(*verbose)
+	spdxOperator, opErr := parseLicenseOperator(opts.licenseOp)
+	if opErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", opErr)
+		return exitInvalidArgs
+	}
+
+	cfg, cfgErr := newRunConfig(opts)
+	if cfgErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", cfgErr)
+		return exitInvalidArgs
+	}
+
+	if opts.clipboard {
+		// Copy whatever the command printed, once it is done
+		var output bytes.Buffer
+		cfg.Stdout = io.MultiWriter(cfg.Stdout, &output)
+		defer func() {
+			copyToClipboard(cfg.Stderr, clipboard.WriteAll, output.String())
+		}()
+	}
+
+	return runCommand(cfg, opts, spdxOperator, flag.Args())
+}
+
+// runCommand runs the subcommand or the purl lookup selected by the positional arguments.
+func runCommand(cfg RunConfig, opts cliOptions, spdxOperator string, args []string) int {
+	// Dispatch subcommands
+	if len(args) > 0 && args[0] == configCommand {
+		return runConfig(cfg, flag.CommandLine, args[1:])
+	}
+	if len(args) > 0 && args[0] == authCommand {
+		return runAuth(cfg, args[1:])
+	}
+	if len(args) > 0 && args[0] == generateCommand {
+		return runGenerate(cfg, func() (Service, error) { return setupService(cfg, opts) }, args[1:])
+	}
+	if len(args) > 0 && args[0] == searchCommand {
+		service, err := setupService(cfg, opts)
+		if err != nil {
+			fmt.Fprintf(cfg.Stderr, "Error: %v\n", err)
+			return exitInvalidArgs
+		}
+		return runSearch(cfg, service, args[1:])
+	}
 
 	// Get the purl from remaining arguments
-	args := flag.Args()
 	if len(args) == 0 {
-		fmt.Fprintf(os.Stderr, "Error: purl argument is required\n\n")
-		printUsage()
+		fmt.Fprintf(cfg.Stderr, "Error: purl argument is required\n\n")
+		printUsage(cfg.Stderr)
 		return exitInvalidArgs
 	}
 	if len(args) > 1 {
-		fmt.Fprintf(os.Stderr, "Error: Too many arguments. Expected 1 purl, got %d\n\n", len(args))
-		printUsage()
+		fmt.Fprintf(cfg.Stderr, "Error: Too many arguments. Expected 1 purl, got %d\n\n", len(args))
+		printUsage(cfg.Stderr)
 		return exitInvalidArgs
 	}
 
 	purlString := args[0]
 
 	// Parse the purl
-	logger.Debug("parsing purl", "purl", purlString)
+	cfg.Logger.Debug("parsing purl", "purl", purlString)
 	purl, err := packageurl.FromString(purlString)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Invalid purl format: %v\n", err)
+		fmt.Fprintf(cfg.Stderr, "Error: Invalid purl format: %v\n", err)
 		return exitInvalidPurl
 	}
-
-	// Create HTTP client with timeout
-	httpClient := &http.Client{
-		Timeout: *timeout,
+	if opts.verifyCanon {
+		warnIfNotCanonical(cfg.Stderr, purlString, purl)
 	}
+	purl = normalizePURL(cfg.Logger, purl)
 
 	// Create service
-	service := createService(httpClient, *email)
+	service, err := setupService(cfg, opts)
+	if err != nil {
+		fmt.Fprintf(cfg.Stderr, "Error: %v\n", err)
+		return exitInvalidArgs
+	}
+
+	if opts.listVersions {
+		return runListVersions(cfg, service, purl)
+	}
+	if opts.allResults {
+		return runAllResults(cfg, service, purl, purlString, spdxOperator)
+	}
 
 	// Delegate to runWithService for the core logic
-	return runWithService(service, logger, purl, purlString, *verbose, *outputJSON, *timeout)
+	return runWithService(cfg, service, purl, purlString, spdxOperator)
+}
+
+// cliOptions holds the values of the command-line flags.
+type cliOptions struct {
+	outputJSON   bool
+	fingerprint  bool
+	wrapJSON     bool
+	format       string
+	verbose      bool
+	logFormat    string
+	showVersion  bool
+	timeout      time.Duration
+	email        string
+	token        string
+	backend      string
+	userAgent    string
+	registryURL  string
+	listVersions bool
+	allResults   bool
+	verifyCanon  bool
+	dryRun       bool
+	licenseOp    string
+	scorecard    bool
+	clipboard    bool
+}
+
+// defineFlags defines the command-line flags on flag.CommandLine, storing their values in opts.
+func defineFlags(opts *cliOptions) {
+	flag.BoolVar(&opts.outputJSON, "json", false, "Output as JSON (same as -format json)")
+	flag.BoolVar(&opts.fingerprint, "fingerprint", false,
+		"Output a SHA-256 fingerprint of the package info (same as -format fingerprint)")
+	flag.StringVar(&opts.format, "format", formatText, "Output format: text, json, toml, fingerprint")
+	flag.BoolVar(&opts.wrapJSON, "wrap-json", false,
+		"Wrap the JSON output in an object with the time the package info was fetched")
+	flag.BoolVar(&opts.verbose, "v", false, "Verbose output (debug mode)")
+	flag.StringVar(&opts.logFormat, "log-format", logFormatText, "Format of the debug logs: text, json")
+	flag.BoolVar(&opts.showVersion, "version", false, "Show version and exit")
+	flag.DurationVar(&opts.timeout, "timeout", defaultTimeoutSec*time.Second, "HTTP request timeout")
+	flag.StringVar(&opts.email, "email", "", "Email for polite pool (optional)")
+	flag.StringVar(&opts.token, "token", "",
+		"Bearer token for API requests (default $"+tokenEnvVar+", then the key stored with 'auth set')")
+	flag.StringVar(&opts.backend, "backend", backendEcosystems, backendUsage())
+	flag.StringVar(&opts.userAgent, "user-agent", "", "User-Agent for Ecosystems API requests (optional)")
+	flag.StringVar(&opts.registryURL, "registry-url", "", registryURLUsage)
+	flag.BoolVar(&opts.dryRun, "dry-run", false, "Print the requests that would be made instead of sending them")
+	flag.BoolVar(&opts.clipboard, "clipboard", false, "Also copy the output to the system clipboard")
+
+	flag.Bool("fail-on-not-found", false, "Deprecated: not found packages always exit with code 6")
+	flag.BoolVar(&opts.listVersions, "versions", false, "List all available versions of the package")
+	flag.BoolVar(&opts.allResults, "all-results", false,
+		"Return all packages matching the purl (e.g., mirrored in several registries), not just the first")
+	flag.BoolVar(&opts.verifyCanon, "verify-canonical", false, "Warn if the purl is not in canonical form")
+	flag.StringVar(&opts.licenseOp, "license-operator", licenseOperatorAnd,
+		"Operator joining multiple licenses in the SPDX expression: and, or")
+	flag.BoolVar(&opts.scorecard, "scorecard", false,
+		"Look up the OpenSSF Scorecard score of the package's repository")
+}
+
+// RunConfig is the configuration shared by the commands, built by run() from the command line.
+//
+// The commands write to Stdout and Stderr instead of os.Stdout and os.Stderr so that they can be tested.
+type RunConfig struct {
+	// Stdin is read by auth set when the API key is not given with -key.
+	Stdin io.Reader
+	// Stdout receives the command output.
+	Stdout io.Writer
+	// Stderr receives errors and warnings.
+	Stderr io.Writer
+	// Logger is the debug logger.
+	Logger *slog.Logger
+	// Verbose prints error details.
+	Verbose bool
+	// Format is the output format (formatText, formatJSON, formatTOML or formatFingerprint).
+	Format string
+	// WrapJSON wraps the JSON output in a wrappedJSONOutput.
+	WrapJSON bool
+	// Timeout is the timeout of the whole command.
+	Timeout time.Duration
+	// Keyring stores the API keys of the backends.
+	Keyring tokenKeyring
+	// Scorecard looks up the OpenSSF Scorecard score of the packages (nil unless -scorecard is set).
+	Scorecard *ScorecardClient
+}
+
+// warnIfNotCanonical prints a warning to w if the purl string differs from its canonical form.
+func warnIfNotCanonical(w io.Writer, purlString string, purl packageurl.PackageURL) {
+	if canonical := purl.String(); canonical != purlString {
+		fmt.Fprintf(w, "Warning: input purl is not canonical. Canonical form: %s\n", canonical)
+	}
 }
 
 // runWithService contains the core logic for fetching and displaying package info.
 // This function is separated to enable testing with mock services.
 func runWithService(
+	cfg RunConfig,
 	service Service,
-	logger *slog.Logger,
 	purl packageurl.PackageURL,
 	purlString string,
-	verbose bool,
-	outputJSON bool,
-	timeout time.Duration,
+	licenseOperator string,
 ) int {
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
 	defer cancel()
 
 	// Get package info
-	logger.Debug("fetching package info", "purl", purlString)
-	info, err := service.GetPackageInfo(ctx, purl)
+	cfg.Logger.Debug("fetching package info", "purl", purlString)
+	fetchedAt := time.Now()
+	info, err := service.GetPackageInfo(ctx, lookupPURL(purl))
 	if err != nil {
-		if verbose {
-			fmt.Fprintf(os.Stderr, "Error: Failed to get package info: %v\n", err)
-		} else {
-			fmt.Fprintf(os.Stderr, "Error: Failed to get package info\n")
-			fmt.Fprintf(os.Stderr, "Use -v flag for more details\n")
+		return commandError(cfg, "Failed to get package info", err)
+	}
+	info = completePackageInfo(info, purl, purlString, licenseOperator)
+	if cfg.Scorecard != nil {
+		info = addScorecard(ctx, cfg, info)
+	}
+
+	// Output the result
+	var printErr error
+	if cfg.WrapJSON && cfg.Format == formatJSON {
+		printErr = printWrappedJSONOutput(cfg.Stdout, info, fetchedAt)
+	} else {
+		printErr = printOutput(cfg.Stdout, info, cfg.Format)
+	}
+	if printErr != nil {
+		fmt.Fprintf(cfg.Stderr, "Error: %v\n", printErr)
+		return exitRuntimeError
+	}
+
+	return exitSuccess
+}
+
+// latestVersion is the purl version standing for the latest version of the package.
+const latestVersion = "latest"
+
+// resolvesLatestVersion reports whether the purl has no version or the version "latest",
+// in which case the latest version of the package is looked up.
+func resolvesLatestVersion(purl packageurl.PackageURL) bool {
+	return purl.Version == "" || strings.EqualFold(purl.Version, latestVersion)
+}
+
+// lookupPURL returns the purl to pass to the services: "latest" is not a version known to the registries,
+// so it is removed, and the services return the latest version as for a purl without version.
+func lookupPURL(purl packageurl.PackageURL) packageurl.PackageURL {
+	if resolvesLatestVersion(purl) {
+		purl.Version = ""
+	}
+	return purl
+}
+
+// normalizePURL returns the purl with its name normalized as the registry does, where the normalization
+// of packageurl-go falls short: it only lowercases PyPI names and replaces "_" with "-".
+func normalizePURL(logger *slog.Logger, purl packageurl.PackageURL) packageurl.PackageURL {
+	if purl.Type == packageurl.TypePyPi {
+		if name := normalizePyPIName(purl.Name); name != purl.Name {
+			logger.Debug("normalized PyPI package name", "name", purl.Name, "normalized", name)
+			purl.Name = name
+		}
+	}
+	return purl
+}
+
+// normalizePyPIName returns the normalized form of a PyPI package name, under which PyPI and the APIs mirroring
+// it index the package: lowercase, with runs of "-", "_" and "." replaced with "-" (PEP 503).
+//
+// Valid names start and end with a letter or digit, so the leading and trailing separators of invalid names
+// are dropped rather than replaced.
+func normalizePyPIName(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == '-' || r == '_' || r == '.'
+	}), "-")
+}
+
+// completePackageInfo sets the fields of the package info computed client-side, so that every backend gets them.
+//
+// If the version of the purl was resolved to the latest version, ResolvedPURL is the purl with that version,
+// and RegistryURL links to the page of that version, or to the package page if the service returned no version.
+func completePackageInfo(
+	info PackageInfo,
+	purl packageurl.PackageURL,
+	purlString string,
+	licenseOperator string,
+) PackageInfo {
+	info.OriginalPURL = purlString
+	if info.Licenses == nil {
+		info.Licenses = []string{}
+	}
+	if !info.NonSPDXLicenses {
+		info.LicenseSPDXExpression = spdxExpression(info.Licenses, licenseOperator)
+	}
+
+	if resolvesLatestVersion(purl) {
+		purl.Version = info.Version
+		if info.Version != "" {
+			info.ResolvedPURL = purl.String()
+		}
+	}
+	if info.RegistryURL == "" && !info.PrivateRegistry {
+		info.RegistryURL = registryPageURL(purl)
+	}
+	if info.ChangelogURL == "" {
+		info.ChangelogURL = releasesPageURL(info.RepositoryURL)
+	}
+	info.SecurityPolicyURL = securityPolicyURL(info.RepositoryURL)
+
+	return info
+}
+
+// versionInfo is the JSON output of -version.
+type versionInfo struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	GoVersion string `json:"go_version"`
+	BuildTime string `json:"build_time,omitempty"`
+	Commit    string `json:"commit,omitempty"`
+}
+
+// currentVersionInfo returns the version and build metadata of the CLI.
+//
+// Without -ldflags, the commit and time come from the VCS information embedded by go build,
+// in which case the time is the time of the commit.
+func currentVersionInfo() versionInfo {
+	info := versionInfo{
+		Name:      "purlinfo",
+		Version:   version,
+		GoVersion: runtime.Version(),
+		BuildTime: buildTime,
+		Commit:    buildCommit,
+	}
+
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range buildInfo.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = setting.Value
+			case setting.Key == "vcs.time" && info.BuildTime == "":
+				info.BuildTime = setting.Value
+			}
 		}
+	}
+
+	return info
+}
+
+// printVersion prints the version of the CLI to w, with the build metadata as JSON if asJSON is set.
+func printVersion(w io.Writer, asJSON bool) error {
+	info := currentVersionInfo()
+	if !asJSON {
+		fmt.Fprintf(w, "purlinfo version %s\n", info.Version)
+		return nil
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if encodeErr := encoder.Encode(info); encodeErr != nil {
+		return fmt.Errorf("failed to encode JSON: %w", encodeErr)
+	}
+	return nil
+}
+
+// newRunConfig returns the configuration of the command selected by the flags, writing to stdout and stderr.
+func newRunConfig(opts cliOptions) (RunConfig, error) {
+	logger, err := setupLogger(opts.verbose, opts.logFormat, os.Stderr)
+	if err != nil {
+		return RunConfig{}, err
+	}
+
+	format, err := outputFormat(opts)
+	if err != nil {
+		return RunConfig{}, err
+	}
+
+	cfg := RunConfig{
+		Stdin:    os.Stdin,
+		Stdout:   os.Stdout,
+		Stderr:   os.Stderr,
+		Logger:   logger,
+		Verbose:  opts.verbose,
+		Format:   format,
+		WrapJSON: opts.wrapJSON,
+		Timeout:  opts.timeout,
+		Keyring:  osKeyring{},
+	}
+	if opts.scorecard {
+		// Without the token, which is only meant for the backend
+		cfg.Scorecard = NewScorecardClient(ScorecardClientOptions{Client: newHTTPClient(opts.timeout, "", "", nil)})
+	}
+	return cfg, nil
+}
+
+// outputFormat returns the output format selected by the -format, -json and -fingerprint flags.
+func outputFormat(opts cliOptions) (string, error) {
+	switch {
+	case opts.outputJSON:
+		return formatJSON, nil
+	case opts.fingerprint:
+		return formatFingerprint, nil
+	}
+	switch opts.format {
+	case formatText, formatJSON, formatTOML, formatFingerprint:
+		return opts.format, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q (expected %s, %s, %s or %s)",
+			opts.format, formatText, formatJSON, formatTOML, formatFingerprint)
+	}
+}
+
+// commandError reports an error returned by a service to cfg.Stderr and returns the exit code of the command.
+//
+// The error details are only printed in verbose mode. With -dry-run, the service fails with ErrDryRun
+// after printing its request, which is reported as a success.
+func commandError(cfg RunConfig, message string, err error) int {
+	if errors.Is(err, ErrDryRun) {
+		return exitSuccess
+	}
+
+	if cfg.Verbose {
+		fmt.Fprintf(cfg.Stderr, "Error: %s: %v\n", message, err)
+	} else {
+		fmt.Fprintf(cfg.Stderr, "Error: %s\n", message)
+		fmt.Fprintf(cfg.Stderr, "Use -v flag for more details\n")
+	}
+	return exitCodeForError(err)
+}
+
+// exitCodeForError returns the exit code for an error returned by a service.
+func exitCodeForError(err error) int {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, ErrPackageNotFound):
+		return exitNotFound
+	case errors.Is(err, ErrRateLimited):
+		return exitRateLimited
+	case errors.Is(err, ErrAPIError), errors.Is(err, ErrInvalidResponse):
+		return exitAPIError
+	case errors.Is(err, ErrInvalidPURL):
+		return exitInvalidPurl
+	case errors.Is(err, ErrUnsupportedEcosystem):
+		// The purl type does not match the selected backend
+		return exitInvalidArgs
+	case errors.As(err, &netErr), errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return exitNetworkError
+	default:
 		return exitRuntimeError
 	}
+}
 
-	// Output the result
-	if printErr := printOutput(info, outputJSON); printErr != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", printErr)
+// runListVersions lists the available versions of a package.
+// The service must implement VersionLister.
+func runListVersions(cfg RunConfig, service Service, purl packageurl.PackageURL) int {
+	lister, ok := service.(VersionLister)
+	if !ok {
+		fmt.Fprintf(cfg.Stderr, "Error: The selected backend does not support listing versions\n")
+		return exitInvalidArgs
+	}
+
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	cfg.Logger.Debug("listing versions", "purl", purl.String())
+	versions, err := lister.ListVersions(ctx, purl)
+	if err != nil {
+		return commandError(cfg, "Failed to list versions", err)
+	}
+
+	if cfg.Format == formatJSON {
+		encoder := json.NewEncoder(cfg.Stdout)
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(versions); encodeErr != nil {
+			fmt.Fprintf(cfg.Stderr, "Error: failed to encode JSON: %v\n", encodeErr)
+			return exitRuntimeError
+		}
+		return exitSuccess
+	}
+
+	for _, v := range versions {
+		fmt.Fprintln(cfg.Stdout, v)
+	}
+
+	return exitSuccess
+}
+
+// runAllResults fetches and displays every package matching the purl.
+// The service must implement MatchLister.
+func runAllResults(
+	cfg RunConfig,
+	service Service,
+	purl packageurl.PackageURL,
+	purlString string,
+	licenseOperator string,
+) int {
+	lister, ok := service.(MatchLister)
+	if !ok {
+		fmt.Fprintf(cfg.Stderr, "Error: The selected backend does not support -all-results\n")
+		return exitInvalidArgs
+	}
+
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	cfg.Logger.Debug("fetching all matching packages", "purl", purlString)
+	fetchedAt := time.Now()
+	infos, err := lister.ListMatches(ctx, lookupPURL(purl))
+	if err != nil {
+		return commandError(cfg, "Failed to get package info", err)
+	}
+	for i := range infos {
+		infos[i] = completePackageInfo(infos[i], purl, purlString, licenseOperator)
+	}
+
+	var printErr error
+	if cfg.WrapJSON && cfg.Format == formatJSON {
+		printErr = printWrappedJSONOutput(cfg.Stdout, infos, fetchedAt)
+	} else {
+		printErr = printPackageList(cfg.Stdout, infos, cfg.Format)
+	}
+	if printErr != nil {
+		fmt.Fprintf(cfg.Stderr, "Error: %v\n", printErr)
 		return exitRuntimeError
 	}
 
 	return exitSuccess
 }
 
-// printUsage prints the usage message.
-func printUsage() {
-	fmt.Fprintf(os.Stderr, "Usage: %s [OPTIONS] purl\n\n", os.Args[0])
-	fmt.Fprintf(os.Stderr, "Get package information from a package URL (purl).\n\n")
-	fmt.Fprintf(os.Stderr, "Arguments:\n")
-	fmt.Fprintf(os.Stderr, "  purl    Package URL (e.g., pkg:npm/lodash@4.17.21)\n\n")
-	fmt.Fprintf(os.Stderr, "Options:\n")
+// printUsage prints the usage message to w.
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: %s [OPTIONS] purl\n", os.Args[0])
+	fmt.Fprintf(w, "       %s [OPTIONS] search [SEARCH OPTIONS] query\n", os.Args[0])
+	fmt.Fprintf(w, "       %s [OPTIONS] generate [GENERATE OPTIONS]\n", os.Args[0])
+	fmt.Fprintf(w, "       %s [OPTIONS] config show\n", os.Args[0])
+	fmt.Fprintf(w, "       %s [OPTIONS] auth set|get -service backend [-key api-key]\n\n", os.Args[0])
+	fmt.Fprintf(w, "Get package information from a package URL (purl).\n\n")
+	fmt.Fprintf(w, "Arguments:\n")
+	fmt.Fprintf(w, "  purl    Package URL (e.g., pkg:npm/lodash@4.17.21)\n\n")
+	fmt.Fprintf(w, "Commands:\n")
+	fmt.Fprintf(w, "  search    Search for packages (see '%s search -h')\n", os.Args[0])
+	fmt.Fprintf(w, "  generate  Print the canonical purl of a package (see '%s generate -h')\n", os.Args[0])
+	fmt.Fprintf(w, "  config    Show the effective configuration (see '%s config -h')\n", os.Args[0])
+	fmt.Fprintf(w, "  auth      Store API keys in the OS keychain (see '%s auth -h')\n\n", os.Args[0])
+	fmt.Fprintf(w, "Options:\n")
+	flag.CommandLine.SetOutput(w)
 	flag.PrintDefaults()
+	fmt.Fprintf(w, "\nExit codes:\n")
+	fmt.Fprintf(w, "  %d  Success\n", exitSuccess)
+	fmt.Fprintf(w, "  %d  Invalid arguments\n", exitInvalidArgs)
+	fmt.Fprintf(w, "  %d  Invalid purl\n", exitInvalidPurl)
+	fmt.Fprintf(w, "  %d  Network error or timeout\n", exitNetworkError)
+	fmt.Fprintf(w, "  %d  API error\n", exitAPIError)
+	fmt.Fprintf(w, "  %d  Rate limited by the API\n", exitRateLimited)
+	fmt.Fprintf(w, "  %d  Package not found\n", exitNotFound)
+	fmt.Fprintf(w, "  %d  Unexpected error\n", exitRuntimeError)
 }
 
-// setupLogger sets up the logger based on the verbose flag.
-func setupLogger(verbose bool) *slog.Logger {
+// setupLogger sets up the logger writing to w based on the verbose flag, in the given log format.
+func setupLogger(verbose bool, logFormat string, w io.Writer) (*slog.Logger, error) {
 	logLevel := slog.LevelError
 	if verbose {
 		// If verbose is true, set the log level to debug
 		// This will log all messages, including debug messages
 		logLevel = slog.LevelDebug
 	}
-	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+	options := &slog.HandlerOptions{
 		Level: logLevel,
-	}))
+	}
+
+	switch logFormat {
+	case logFormatText:
+		return slog.New(slog.NewTextHandler(w, options)), nil
+	case logFormatJSON:
+		// One JSON object per line, for log aggregators
+		return slog.New(slog.NewJSONHandler(w, options)), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q (expected %s or %s)", logFormat, logFormatText, logFormatJSON)
+	}
+}
+
+// setupService creates the HTTP client and the service for the backend from the command line options.
+//
+// With -dry-run, the requests of the service are printed to cfg.Stdout instead of being sent.
+func setupService(cfg RunConfig, opts cliOptions) (Service, error) {
+	// Fall back to the environment, then to the keyring, for the token so it stays out of shell history
+	apiToken := opts.token
+	if apiToken == "" {
+		apiToken = os.Getenv(tokenEnvVar)
+	}
+	if apiToken == "" {
+		apiToken = keyringToken(cfg.Keyring, cfg.Logger, opts.backend)
+	}
+	cfg.Logger.Debug("configuring HTTP client", "timeout", opts.timeout, "token", maskToken(apiToken))
+
+	if opts.backend == backendGitHubPackages && apiToken == "" {
+		return nil, fmt.Errorf("the %s backend requires -token or $%s", backendGitHubPackages, tokenEnvVar)
+	}
+
+	// Create HTTP client with timeout
+	var dryRun io.Writer
+	if opts.dryRun {
+		fmt.Fprintf(cfg.Stdout, "Backend: %s\n", opts.backend)
+		dryRun = cfg.Stdout
+	}
+	if opts.registryURL != "" {
+		if u, err := url.Parse(opts.registryURL); err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid registry URL %q", opts.registryURL)
+		}
+		cfg.Logger.Debug("using registry mirror", "url", opts.registryURL)
+	}
+
+	httpClient := newHTTPClient(opts.timeout, apiToken, backendAPIHost(opts.backend, opts.registryURL), dryRun)
+
+	registryURL := strings.TrimSuffix(opts.registryURL, "/")
+	return createService(cfg, opts, httpClient, registryURL)
+}
+
+// newHTTPClient creates the HTTP client, authenticating the requests to authHost when token is set.
+// If dryRun is not nil, requests are printed to it instead of being sent (see DryRunMiddleware).
+//
+// The client uses http.DefaultTransport, which keeps connections alive, so consecutive
+// requests to the same host reuse a connection instead of opening a new one. Requests wait
+// for the rate limit budget to be reset once it is exhausted (see RateLimitMiddleware).
+func newHTTPClient(timeout time.Duration, token, authHost string, dryRun io.Writer) *http.Client {
+	transport := RateLimitMiddleware()(http.DefaultTransport)
+	if dryRun != nil {
+		transport = DryRunMiddleware(dryRun)(transport)
+	}
+	if token != "" {
+		transport = AuthMiddleware(token, authHost)(transport)
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
+
+// backend is a backend that can be selected with -backend.
+type backend struct {
+	// name is the value of -backend selecting the backend.
+	name string
+	// baseURL is the default base URL of the backend API. Only requests to its host are authenticated.
+	baseURL string
+	// newService creates the service of the backend. If registryURL is not empty, it replaces
+	// the default base URL of the backend.
+	newService func(cfg RunConfig, opts cliOptions, httpClient *http.Client, registryURL string) Service
+}
+
+// backends returns the backends, in the order they are listed in the usage message.
+//
+// The -backend usage message, isKnownBackend and createService are all derived from this list.
+func backends() []backend {
+	return []backend{
+		{
+			name: backendEcosystems, baseURL: ecosystemsBaseURL,
+			newService: func(cfg RunConfig, opts cliOptions, c *http.Client, u string) Service {
+				return NewEcosystemsService(EcosystemsServiceOptions{
+					BaseURL:   u,
+					Client:    c,
+					Email:     opts.email,
+					UserAgent: opts.userAgent,
+					Logger:    cfg.Logger,
+					Warnings:  cfg.Stderr,
+				})
+			},
+		},
+		{
+			name: backendGitHubPackages, baseURL: githubAPIBaseURL,
+			newService: func(_ RunConfig, _ cliOptions, c *http.Client, u string) Service {
+				return NewGitHubPackagesService(GitHubPackagesServiceOptions{BaseURL: u, Client: c})
+			},
+		},
+		{
+			name: backendGitHubActions, baseURL: githubAPIBaseURL,
+			newService: func(_ RunConfig, _ cliOptions, c *http.Client, u string) Service {
+				return NewGitHubActionsService(GitHubActionsServiceOptions{BaseURL: u, Client: c})
+			},
+		},
+		{
+			name: backendRubyGems, baseURL: rubyGemsBaseURL,
+			newService: func(_ RunConfig, _ cliOptions, c *http.Client, u string) Service {
+				return NewRubyGemsService(RubyGemsServiceOptions{BaseURL: u, Client: c})
+			},
+		},
+		{
+			name: backendNuGet, baseURL: nugetServiceIndexURL,
+			newService: func(_ RunConfig, _ cliOptions, c *http.Client, u string) Service {
+				return NewNuGetService(NuGetServiceOptions{ServiceIndexURL: u, Client: c})
+			},
+		},
+		{
+			name: backendMavenCentral, baseURL: mavenCentralBaseURL,
+			newService: func(_ RunConfig, _ cliOptions, c *http.Client, u string) Service {
+				return NewMavenCentralService(MavenCentralServiceOptions{BaseURL: u, Client: c})
+			},
+		},
+		{
+			name: backendGoProxy, baseURL: goProxyBaseURL,
+			newService: func(_ RunConfig, _ cliOptions, c *http.Client, u string) Service {
+				return NewGoModuleProxyService(GoModuleProxyServiceOptions{BaseURL: u, Client: c})
+			},
+		},
+		{
+			name: backendDockerHub, baseURL: dockerHubBaseURL,
+			newService: func(_ RunConfig, _ cliOptions, c *http.Client, u string) Service {
+				return NewDockerHubService(DockerHubServiceOptions{BaseURL: u, Client: c})
+			},
+		},
+		{
+			name: backendHex, baseURL: hexBaseURL,
+			newService: func(_ RunConfig, _ cliOptions, c *http.Client, u string) Service {
+				return NewHexService(HexServiceOptions{BaseURL: u, Client: c})
+			},
+		},
+		{
+			name: backendPub, baseURL: pubBaseURL,
+			newService: func(_ RunConfig, _ cliOptions, c *http.Client, u string) Service {
+				return NewPubService(PubServiceOptions{BaseURL: u, Client: c})
+			},
+		},
+		{
+			name: backendPackagist, baseURL: packagistBaseURL,
+			newService: func(_ RunConfig, _ cliOptions, c *http.Client, u string) Service {
+				return NewPackagistService(PackagistServiceOptions{BaseURL: u, Client: c})
+			},
+		},
+		{
+			name: backendCPAN, baseURL: cpanBaseURL,
+			newService: func(_ RunConfig, _ cliOptions, c *http.Client, u string) Service {
+				return NewCPANService(CPANServiceOptions{BaseURL: u, Client: c})
+			},
+		},
+		{
+			name: backendCRAN, baseURL: cranBaseURL,
+			newService: func(_ RunConfig, _ cliOptions, c *http.Client, u string) Service {
+				return NewCRANService(CRANServiceOptions{BaseURL: u, Client: c})
+			},
+		},
+		{
+			name: backendHackage, baseURL: hackageBaseURL,
+			newService: func(_ RunConfig, _ cliOptions, c *http.Client, u string) Service {
+				return NewHackageService(HackageServiceOptions{BaseURL: u, Client: c})
+			},
+		},
+	}
+}
+
+// backendNames returns the names of the backends.
+func backendNames() []string {
+	all := backends()
+	names := make([]string, 0, len(all))
+	for _, b := range all {
+		names = append(names, b.name)
+	}
+	return names
+}
+
+// backendUsage returns the usage message of the -backend flag.
+func backendUsage() string {
+	return "Backend to query: " + strings.Join(backendNames(), ", ")
+}
+
+// backendAPIHost returns the host of the API of the backend, which receives the token: the host of
+// registryURL if it is set, else the host of the default base URL of the backend.
+func backendAPIHost(name, registryURL string) string {
+	if registryURL == "" {
+		for _, b := range backends() {
+			if b.name == name {
+				registryURL = b.baseURL
+			}
+		}
+	}
+	u, err := url.Parse(registryURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// createService creates the service for the backend selected by the command line options.
+//
+// If registryURL is not empty, it replaces the default base URL of the backend.
+func createService(cfg RunConfig, opts cliOptions, httpClient *http.Client, registryURL string) (Service, error) {
+	for _, b := range backends() {
+		if b.name == opts.backend {
+			return b.newService(cfg, opts, httpClient, registryURL), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown backend %q", opts.backend)
+}
+
+// printOutput prints the package info to w in the given output format.
+func printOutput(w io.Writer, info PackageInfo, format string) error {
+	switch format {
+	case formatJSON:
+		return printJSONOutput(w, info)
+	case formatText:
+		return printHumanReadableOutput(w, info)
+	case formatTOML:
+		return printTOMLOutput(w, info)
+	case formatFingerprint:
+		return printFingerprintOutput(w, info)
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+// printPackageList prints a list of packages to w in the given output format.
+//
+// The JSON output is an array; the TOML output is an array of tables; the fingerprint output has one fingerprint
+// per line; the text output separates the packages with blank lines.
+func printPackageList(w io.Writer, results []PackageInfo, format string) error {
+	switch format {
+	case formatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(results); encodeErr != nil {
+			return fmt.Errorf("failed to encode JSON: %w", encodeErr)
+		}
+		return nil
+	case formatTOML:
+		return printTOMLPackageList(w, results)
+	case formatFingerprint:
+		for _, info := range results {
+			if err := printFingerprintOutput(w, info); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for i, info := range results {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if err := printHumanReadableOutput(w, info); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-// createService creates the service.
-func createService(httpClient *http.Client, email string) Service {
-	return NewEcosystemsService(EcosystemsServiceOptions{
-		Client: httpClient,
-		Email:  email,
-	})
+// wrappedJSONOutput is the JSON output with -wrap-json.
+type wrappedJSONOutput struct {
+	// FetchedAt is the UTC time when the package info was requested, in RFC 3339 format.
+	FetchedAt string `json:"fetched_at"`
+	// Data is the package info, or the list of packages with -all-results.
+	Data any `json:"data"`
 }
 
-// printOutput prints the output based on the outputJSON flag.
-func printOutput(info PackageInfo, outputJSON bool) error {
-	if outputJSON {
-		return printJSONOutput(info)
+// printWrappedJSONOutput prints data as JSON, wrapped in an object with the time it was fetched.
+func printWrappedJSONOutput(w io.Writer, data any, fetchedAt time.Time) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	output := wrappedJSONOutput{FetchedAt: fetchedAt.UTC().Format(time.RFC3339), Data: data}
+	if encodeErr := encoder.Encode(output); encodeErr != nil {
+		return fmt.Errorf("failed to encode JSON: %w", encodeErr)
 	}
-	return printHumanReadableOutput(info)
+	return nil
 }
 
 // printJSONOutput prints the package info as JSON.
-func printJSONOutput(info PackageInfo) error {
-	encoder := json.NewEncoder(os.Stdout)
+func printJSONOutput(w io.Writer, info PackageInfo) error {
+	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
 	if encodeErr := encoder.Encode(info); encodeErr != nil {
 		return fmt.Errorf("failed to encode JSON: %w", encodeErr)
@@ -185,39 +990,86 @@ func printJSONOutput(info PackageInfo) error {
 }
 
 // printHumanReadableOutput prints the package info in human-readable format.
-func printHumanReadableOutput(info PackageInfo) error {
-	fmt.Fprintf(os.Stdout, "Name:            %s\n", info.Name)
-	fmt.Fprintf(os.Stdout, "Version:         %s\n", info.Version)
-	fmt.Fprintf(os.Stdout, "Ecosystem:       %s\n", info.Ecosystem)
+func printHumanReadableOutput(w io.Writer, info PackageInfo) error {
+	fmt.Fprintf(w, "%-*s%s\n", labelColumnWidth, "Name:", info.Name)
+	fmt.Fprintf(w, "%-*s%s\n", labelColumnWidth, "Version:", info.Version)
+	fmt.Fprintf(w, "%-*s%s\n", labelColumnWidth, "Ecosystem:", info.Ecosystem)
+	if info.ResolvedPURL != "" {
+		printOptionalField(w, "PURL:", info.OriginalPURL)
+		printOptionalField(w, "Resolved PURL:", info.ResolvedPURL)
+	}
 
-	printLicenses(info.Licenses)
-	printOptionalField("Description:", info.Description)
-	printOptionalField("Homepage:", info.Homepage)
-	printOptionalField("RepositoryURL:", info.RepositoryURL)
-	printOptionalField("DocumentationURL:", info.DocumentationURL)
+	printLicenses(w, info.Licenses)
+	if info.LicenseSPDXExpression != "" {
+		printOptionalField(w, "SPDX Expression:", info.LicenseSPDXExpression)
+	}
+	printOptionalField(w, "Description:", info.Description)
+	printOptionalField(w, "Homepage:", info.Homepage)
+	printOptionalField(w, "Registry:", info.RegistryURL)
+	printOptionalField(w, "RepositoryURL:", info.RepositoryURL)
+	printOptionalField(w, "DocumentationURL:", info.DocumentationURL)
+	if info.BugTrackerURL != "" {
+		printOptionalField(w, "Bug Tracker:", info.BugTrackerURL)
+	}
+	if info.ChangelogURL != "" {
+		printOptionalField(w, "Changelog:", info.ChangelogURL)
+	}
+	if info.SecurityPolicyURL != "" {
+		printOptionalField(w, "Security Policy:", info.SecurityPolicyURL)
+	}
+	if info.DependencyCount != nil {
+		printOptionalField(w, "Dependencies:", strconv.Itoa(*info.DependencyCount))
+	}
+	if info.ContributorCount != nil {
+		printOptionalField(w, "Contributors:", strconv.Itoa(*info.ContributorCount))
+	}
+	if info.LastCommitDate != nil {
+		printOptionalField(w, "Last Commit:", info.LastCommitDate.UTC().Format(time.DateOnly))
+	}
+	if info.ForksCount != nil {
+		printOptionalField(w, "Forks:", strconv.Itoa(*info.ForksCount))
+	}
+	if info.WatchersCount != nil {
+		printOptionalField(w, "Watchers:", strconv.Itoa(*info.WatchersCount))
+	}
+	if info.DownloadCount != nil {
+		printOptionalField(w, "Downloads:", strconv.Itoa(*info.DownloadCount))
+	}
+	if info.LastPublishedDate != nil {
+		printOptionalField(w, "Last Published:", info.LastPublishedDate.UTC().Format(time.DateOnly))
+	}
+	if info.ScorecardScore != nil {
+		scorecard := fmt.Sprintf("%.1f/10", *info.ScorecardScore)
+		if info.ScorecardDate != nil {
+			scorecard += " (" + *info.ScorecardDate + ")"
+		}
+		printOptionalField(w, "Scorecard:", scorecard)
+	}
 
 	return nil
 }
 
 // printLicenses prints the licenses field.
-func printLicenses(licenses []string) {
+func printLicenses(w io.Writer, licenses []string) {
 	if len(licenses) > 0 {
-		fmt.Fprintf(os.Stdout, "Licenses:        %s\n", strings.Join(licenses, ", "))
+		fmt.Fprintf(w, "%-*s%s\n", labelColumnWidth, "Licenses:", strings.Join(licenses, ", "))
 	} else {
-		fmt.Fprintf(os.Stdout, "Licenses:        (none)\n")
+		fmt.Fprintf(w, "%-*s(none)\n", labelColumnWidth, "Licenses:")
 	}
 }
 
+// labelColumnWidth is the width of the label column of the text output: the longest label,
+// "DocumentationURL:" (17 chars), followed by a space, so that all field values are aligned.
+const labelColumnWidth = 18
+
 // printOptionalField prints an optional field (empty string if not available).
-func printOptionalField(label string, value string) {
-	// labelColumnWidth is set to 17 to match the longest label "DocumentationURL:" (17 chars).
-	// This ensures all field values are aligned at the same column.
-	const labelColumnWidth = 17
-	padding := labelColumnWidth - len(label)
+func printOptionalField(w io.Writer, label string, value string) {
+	// Labels that fill or overflow the column are still separated from the value by a space.
+	padding := max(labelColumnWidth-len(label), 1)
 
 	if value != "" {
-		fmt.Fprintf(os.Stdout, "%s%*s%s\n", label, padding, "", value)
+		fmt.Fprintf(w, "%s%*s%s\n", label, padding, "", value)
 	} else {
-		fmt.Fprintf(os.Stdout, "%s%*s(none)\n", label, padding, "")
+		fmt.Fprintf(w, "%s%*s(none)\n", label, padding, "")
 	}
 }
@@ -6,15 +6,21 @@ import (
 	"encoding/json"
 	"errors"
 	"flag"
+	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"runtime"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/package-url/packageurl-go"
+	"github.com/zalando/go-keyring"
 )
 
 // mockService is a mock implementation of the Service interface for testing.
@@ -27,22 +33,73 @@ func (m *mockService) GetPackageInfo(_ context.Context, _ packageurl.PackageURL)
 	return m.info, m.err
 }
 
-// TestPrintUsage tests the printUsage function.
-func TestPrintUsage(t *testing.T) {
-	// Note: Cannot use t.Parallel() because test modifies global os.Stderr
+// fakeKeyring is an in-memory tokenKeyring for testing.
+type fakeKeyring struct {
+	mu     sync.Mutex
+	tokens map[string]string
+	err    error
+}
 
-	// Capture stderr
-	oldStderr := os.Stderr
-	r, w, _ := os.Pipe()
-	os.Stderr = w
+func (k *fakeKeyring) Get(backend string) (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.err != nil {
+		return "", k.err
+	}
+	token, ok := k.tokens[backend]
+	if !ok {
+		return "", keyring.ErrNotFound
+	}
+	return token, nil
+}
 
-	printUsage()
+func (k *fakeKeyring) Set(backend, token string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
 
-	_ = w.Close()
-	os.Stderr = oldStderr
+	if k.err != nil {
+		return k.err
+	}
+	if k.tokens == nil {
+		k.tokens = make(map[string]string)
+	}
+	k.tokens[backend] = token
+	return nil
+}
+
+// mockVersionLister is a mock implementation of the Service and VersionLister interfaces for testing.
+type mockVersionLister struct {
+	mockService
+
+	versions []string
+}
+
+func (m *mockVersionLister) ListVersions(_ context.Context, _ packageurl.PackageURL) ([]string, error) {
+	return m.versions, m.err
+}
+
+// mockMatchLister is a mock implementation of the Service and MatchLister interfaces for testing.
+type mockMatchLister struct {
+	mockService
+
+	matches []PackageInfo
+}
+
+func (m *mockMatchLister) ListMatches(_ context.Context, _ packageurl.PackageURL) ([]PackageInfo, error) {
+	return m.matches, m.err
+}
+
+// TestPrintUsage tests the printUsage function.
+func TestPrintUsage(t *testing.T) {
+	// Note: Cannot use t.Parallel() because printUsage() sets the output of the global flag.CommandLine
+	oldOutput := flag.CommandLine.Output()
+	t.Cleanup(func() {
+		flag.CommandLine.SetOutput(oldOutput)
+	})
 
 	var buf bytes.Buffer
-	_, _ = io.Copy(&buf, r)
+	printUsage(&buf)
 	output := buf.String()
 
 	// Check that usage contains expected strings
@@ -51,6 +108,7 @@ func TestPrintUsage(t *testing.T) {
 		"Get package information",
 		"Arguments:",
 		"pkg:npm/lodash",
+		"Exit codes:",
 	}
 
 	for _, expected := range expectedStrings {
@@ -65,19 +123,37 @@ func TestSetupLogger(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		name    string
-		verbose bool
-		want    slog.Level
+		name      string
+		verbose   bool
+		logFormat string
+		want      slog.Level
+		wantJSON  bool
 	}{
 		{
-			name:    "verbose mode",
-			verbose: true,
-			want:    slog.LevelDebug,
+			name:      "verbose mode",
+			verbose:   true,
+			logFormat: logFormatText,
+			want:      slog.LevelDebug,
+		},
+		{
+			name:      "non-verbose mode",
+			verbose:   false,
+			logFormat: logFormatText,
+			want:      slog.LevelError,
+		},
+		{
+			name:      "verbose mode with JSON logs",
+			verbose:   true,
+			logFormat: logFormatJSON,
+			want:      slog.LevelDebug,
+			wantJSON:  true,
 		},
 		{
-			name:    "non-verbose mode",
-			verbose: false,
-			want:    slog.LevelError,
+			name:      "non-verbose mode with JSON logs",
+			verbose:   false,
+			logFormat: logFormatJSON,
+			want:      slog.LevelError,
+			wantJSON:  true,
 		},
 	}
 
@@ -85,32 +161,104 @@ func TestSetupLogger(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			logger := setupLogger(tt.verbose)
-			if logger == nil {
-				t.Fatal("setupLogger() returned nil")
+			var buf bytes.Buffer
+			logger, err := setupLogger(tt.verbose, tt.logFormat, &buf)
+			if err != nil {
+				t.Fatalf("setupLogger() unexpected error = %v", err)
+			}
+
+			if !logger.Enabled(context.Background(), tt.want) {
+				t.Errorf("setupLogger() level %v is not enabled", tt.want)
+			}
+
+			logger.Debug("debug message")
+			logger.Error("error message")
+
+			output := buf.String()
+			if got := strings.Contains(output, "debug message"); got != tt.verbose {
+				t.Errorf("setupLogger() logged debug message = %v, want %v\nGot: %s", got, tt.verbose, output)
+			}
+			if !strings.Contains(output, "error message") {
+				t.Errorf("setupLogger() output missing error message\nGot: %s", output)
 			}
 
-			// Logger should be configured but we can't easily inspect the level
-			// We mainly test that it doesn't panic and returns a logger
+			// Every line is a JSON object with the JSON log format
+			for line := range strings.Lines(output) {
+				if got := json.Valid([]byte(line)); got != tt.wantJSON {
+					t.Errorf("setupLogger() line %q is JSON = %v, want %v", line, got, tt.wantJSON)
+				}
+			}
 		})
 	}
 }
 
+// TestSetupLogger_UnknownFormat tests that setupLogger rejects an unknown log format.
+func TestSetupLogger_UnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if _, err := setupLogger(true, "xml", &buf); err == nil {
+		t.Error("setupLogger() error = nil, want error")
+	}
+}
+
 // TestCreateService tests the createService function.
 func TestCreateService(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		name       string
+		backend    string
 		httpClient *http.Client
+		wantType   string
+		wantErr    bool
 	}{
 		{
 			name:       "with nil client",
+			backend:    backendEcosystems,
 			httpClient: nil,
+			wantType:   "*main.EcosystemsService",
 		},
 		{
 			name:       "with custom client",
+			backend:    backendEcosystems,
+			httpClient: &http.Client{Timeout: 10 * time.Second},
+			wantType:   "*main.EcosystemsService",
+		},
+		{
+			name:       "github packages backend",
+			backend:    backendGitHubPackages,
+			httpClient: &http.Client{Timeout: 10 * time.Second},
+			wantType:   "*main.GitHubPackagesService",
+		},
+		{
+			name:       "rubygems backend",
+			backend:    backendRubyGems,
+			httpClient: &http.Client{Timeout: 10 * time.Second},
+			wantType:   "*main.RubyGemsService",
+		},
+		{
+			name:       "nuget backend",
+			backend:    backendNuGet,
+			httpClient: &http.Client{Timeout: 10 * time.Second},
+			wantType:   "*main.NuGetService",
+		},
+		{
+			name:       "maven-central backend",
+			backend:    backendMavenCentral,
+			httpClient: &http.Client{Timeout: 10 * time.Second},
+			wantType:   "*main.MavenCentralService",
+		},
+		{
+			name:       "goproxy backend",
+			backend:    backendGoProxy,
 			httpClient: &http.Client{Timeout: 10 * time.Second},
+			wantType:   "*main.GoModuleProxyService",
+		},
+		{
+			name:    "unknown backend",
+			backend: "unknown",
+			wantErr: true,
 		},
 	}
 
@@ -118,20 +266,254 @@ func TestCreateService(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			service := createService(tt.httpClient, "")
+			cfg, _, _ := newTestRunConfig(false, formatText)
+			service, err := createService(cfg, cliOptions{backend: tt.backend}, tt.httpClient, "")
+			if tt.wantErr {
+				if err == nil {
+					t.Error("createService() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("createService() unexpected error = %v", err)
+			}
 			if service == nil {
 				t.Fatal("createService() returned nil")
 			}
 
-			// Verify it returns an EcosystemsService
-			ecosystemsService, ok := service.(*EcosystemsService)
-			if !ok {
-				t.Errorf("createService() returned %T, want *EcosystemsService", service)
+			if gotType := fmt.Sprintf("%T", service); gotType != tt.wantType {
+				t.Errorf("createService() returned %s, want %s", gotType, tt.wantType)
 			}
 
 			// Verify the HTTP client is set correctly
-			if tt.httpClient != nil && ecosystemsService.client != tt.httpClient {
-				t.Error("createService() did not use the provided HTTP client")
+			if ecosystemsService, ok := service.(*EcosystemsService); ok {
+				if tt.httpClient != nil && ecosystemsService.client != tt.httpClient {
+					t.Error("createService() did not use the provided HTTP client")
+				}
+			}
+		})
+	}
+}
+
+// TestCreateService_RegistryURL tests that createService uses the registry URL as the base URL.
+func TestCreateService_RegistryURL(t *testing.T) {
+	t.Parallel()
+
+	const registryURL = "https://registry.example.com"
+
+	tests := []struct {
+		backend string
+		baseURL func(Service) string
+	}{
+		{backend: backendEcosystems, baseURL: func(s Service) string { return s.(*EcosystemsService).baseURL }},
+		{backend: backendGitHubPackages, baseURL: func(s Service) string { return s.(*GitHubPackagesService).baseURL }},
+		{backend: backendRubyGems, baseURL: func(s Service) string { return s.(*RubyGemsService).baseURL }},
+		{backend: backendNuGet, baseURL: func(s Service) string { return s.(*NuGetService).serviceIndexURL }},
+		{backend: backendMavenCentral, baseURL: func(s Service) string { return s.(*MavenCentralService).baseURL }},
+		{backend: backendGoProxy, baseURL: func(s Service) string { return s.(*GoModuleProxyService).baseURL }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.backend, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, _, _ := newTestRunConfig(false, formatText)
+			service, err := createService(cfg, cliOptions{backend: tt.backend}, nil, registryURL)
+			if err != nil {
+				t.Fatalf("createService() unexpected error = %v", err)
+			}
+			if got := tt.baseURL(service); got != registryURL {
+				t.Errorf("base URL = %q, want %q", got, registryURL)
+			}
+		})
+	}
+}
+
+// TestSetupService_RegistryURL tests the validation of the registry URL in setupService.
+func TestSetupService_RegistryURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		registryURL string
+		wantBaseURL string
+		wantErr     bool
+	}{
+		{name: "default", registryURL: "", wantBaseURL: ecosystemsBaseURL},
+		{name: "mirror", registryURL: "http://localhost:4873", wantBaseURL: "http://localhost:4873"},
+		{name: "trailing slash", registryURL: "http://localhost:4873/", wantBaseURL: "http://localhost:4873"},
+		{name: "missing scheme", registryURL: "localhost:4873", wantErr: true},
+		{name: "relative path", registryURL: "/mirror", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, _, _ := newTestRunConfig(false, formatText)
+			service, err := setupService(cfg, cliOptions{
+				backend:     backendEcosystems,
+				registryURL: tt.registryURL,
+				timeout:     time.Second,
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("setupService() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("setupService() unexpected error = %v", err)
+			}
+			if got := service.(*EcosystemsService).baseURL; got != tt.wantBaseURL {
+				t.Errorf("baseURL = %q, want %q", got, tt.wantBaseURL)
+			}
+		})
+	}
+}
+
+// TestBackendAPIHost tests the host that receives the token of each backend.
+func TestBackendAPIHost(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		backend     string
+		registryURL string
+		want        string
+	}{
+		{name: "ecosystems", backend: backendEcosystems, want: "packages.ecosyste.ms"},
+		{name: "github packages", backend: backendGitHubPackages, want: "api.github.com"},
+		{name: "nuget", backend: backendNuGet, want: "api.nuget.org"},
+		{name: "mirror", backend: backendEcosystems, registryURL: "http://localhost:4873", want: "localhost:4873"},
+		{name: "unknown backend", backend: "unknown", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := backendAPIHost(tt.backend, tt.registryURL); got != tt.want {
+				t.Errorf("backendAPIHost(%q, %q) = %q, want %q", tt.backend, tt.registryURL, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSetupService_DryRun tests that services created with -dry-run print their requests.
+func TestSetupService_DryRun(t *testing.T) {
+	t.Parallel()
+
+	cfg, stdout, stderr := newTestRunConfig(false, formatText)
+	service, err := setupService(cfg, cliOptions{
+		backend:     backendEcosystems,
+		registryURL: "http://localhost:4873",
+		timeout:     time.Second,
+		dryRun:      true,
+	})
+	if err != nil {
+		t.Fatalf("setupService() unexpected error = %v", err)
+	}
+
+	purl, _ := packageurl.FromString("pkg:npm/lodash@4.17.21")
+	exitCode := runWithService(cfg, service, purl, "pkg:npm/lodash@4.17.21", "AND")
+
+	if exitCode != exitSuccess {
+		t.Errorf("runWithService() = %d, want %d\nStderr: %s", exitCode, exitSuccess, stderr.String())
+	}
+	wantStdout := "Backend: ecosystems\n" +
+		"GET http://localhost:4873/api/v1/packages/lookup?purl=pkg%3Anpm%2Flodash%404.17.21\n"
+	if !strings.HasPrefix(stdout.String(), wantStdout) {
+		t.Errorf("output = %q, want prefix %q", stdout.String(), wantStdout)
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("stderr = %q, want empty", stderr.String())
+	}
+}
+
+// TestSetupService_KeyringToken tests that setupService falls back to the token stored in the keyring.
+func TestSetupService_KeyringToken(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		keyring *fakeKeyring
+		wantErr bool
+	}{
+		{
+			name:    "token in keyring",
+			keyring: &fakeKeyring{tokens: map[string]string{backendGitHubPackages: "secret"}},
+		},
+		{
+			name:    "token of another backend",
+			keyring: &fakeKeyring{tokens: map[string]string{backendRubyGems: "secret"}},
+			wantErr: true,
+		},
+		{
+			name:    "keyring unavailable",
+			keyring: &fakeKeyring{err: keyring.ErrUnsupportedPlatform},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, _, _ := newTestRunConfig(false, formatText)
+			cfg.Keyring = tt.keyring
+
+			// The github-packages backend fails without a token
+			_, err := setupService(cfg, cliOptions{backend: backendGitHubPackages, timeout: time.Second})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("setupService() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestCommandError tests the commandError function.
+func TestCommandError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		verbose    bool
+		err        error
+		want       int
+		wantStderr string
+	}{
+		{
+			name:       "not verbose",
+			err:        fmt.Errorf("lookup: %w", ErrPackageNotFound),
+			want:       exitNotFound,
+			wantStderr: "Error: Failed to get package info\nUse -v flag for more details\n",
+		},
+		{
+			name:       "verbose",
+			verbose:    true,
+			err:        fmt.Errorf("lookup: %w", ErrPackageNotFound),
+			want:       exitNotFound,
+			wantStderr: "Error: Failed to get package info: lookup: package not found\n",
+		},
+		{
+			name: "dry run",
+			err:  fmt.Errorf("failed to make HTTP request: %w", ErrDryRun),
+			want: exitSuccess,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, _, stderr := newTestRunConfig(tt.verbose, formatText)
+
+			if got := commandError(cfg, "Failed to get package info", tt.err); got != tt.want {
+				t.Errorf("commandError() = %d, want %d", got, tt.want)
+			}
+			if stderr.String() != tt.wantStderr {
+				t.Errorf("commandError() stderr = %q, want %q", stderr.String(), tt.wantStderr)
 			}
 		})
 	}
@@ -139,13 +521,20 @@ func TestCreateService(t *testing.T) {
 
 // TestPrintOutput tests the printOutput function.
 func TestPrintOutput(t *testing.T) {
-	// Note: Cannot use t.Parallel() because subtests modify global os.Stdout
+	t.Parallel()
+
+	scorecardScore, scorecardDate := 7.8, "2024-01-10"
+	contributorCount, forksCount, watchersCount := 342, 7000, 880
+	lastCommitDate := time.Date(2023, time.November, 15, 8, 30, 0, 0, time.UTC)
+	downloadCount := 1000000000
+	lastPublishedDate := time.Date(2024, time.May, 20, 12, 0, 0, 0, time.UTC)
 
 	tests := []struct {
 		name       string
 		info       PackageInfo
-		outputJSON bool
+		format     string
 		wantStdout []string // Strings that should appear in output
+		wantErr    bool
 	}{
 		{
 			name: "human-readable with licenses",
@@ -159,7 +548,7 @@ func TestPrintOutput(t *testing.T) {
 				Ecosystem:        "npm",
 				DocumentationURL: "https://lodash.com/docs",
 			},
-			outputJSON: false,
+			format: formatText,
 			wantStdout: []string{
 				"Name:", "lodash", "Version:", "4.17.21", "Ecosystem:", "npm", "Licenses:", "MIT",
 				"Description:", "Lodash modular utilities.", "Homepage:", "https://lodash.com/",
@@ -179,7 +568,7 @@ func TestPrintOutput(t *testing.T) {
 				Ecosystem:        "npm",
 				DocumentationURL: "",
 			},
-			outputJSON: false,
+			format: formatText,
 			wantStdout: []string{
 				"Name:", "testpkg", "Version:", "1.0.0", "Ecosystem:", "npm",
 				"Licenses:", "(none)", "Description:", "(none)", "Homepage:", "(none)",
@@ -198,7 +587,7 @@ func TestPrintOutput(t *testing.T) {
 				Ecosystem:        "pypi",
 				DocumentationURL: "",
 			},
-			outputJSON: false,
+			format: formatText,
 			wantStdout: []string{
 				"Name:", "requests", "Version:", "2.32.5", "Ecosystem:", "pypi",
 				"Licenses:", "Apache-2.0", "MIT", "Description:", "Python HTTP for Humans.",
@@ -218,7 +607,7 @@ func TestPrintOutput(t *testing.T) {
 				Ecosystem:        "npm",
 				DocumentationURL: "https://lodash.com/docs",
 			},
-			outputJSON: true,
+			format: formatJSON,
 			wantStdout: []string{
 				`"name"`, `"lodash"`, `"version"`, `"4.17.21"`, `"ecosystem"`, `"npm"`,
 				`"licenses"`, `"MIT"`, `"homepage"`, `"https://lodash.com/"`,
@@ -227,29 +616,112 @@ func TestPrintOutput(t *testing.T) {
 				`"documentation_url"`, `"https://lodash.com/docs"`,
 			},
 		},
+		{
+			name: "human-readable with Scorecard score",
+			info: PackageInfo{
+				Name:           "lodash",
+				Version:        "4.17.21",
+				Licenses:       []string{"MIT"},
+				Ecosystem:      "npm",
+				ScorecardScore: &scorecardScore,
+				ScorecardDate:  &scorecardDate,
+			},
+			format:     formatText,
+			wantStdout: []string{"Scorecard:        7.8/10 (2024-01-10)\n"},
+		},
+		{
+			name: "human-readable with contributor count",
+			info: PackageInfo{
+				Name:             "lodash",
+				Version:          "4.17.21",
+				Licenses:         []string{"MIT"},
+				Ecosystem:        "npm",
+				ContributorCount: &contributorCount,
+			},
+			format:     formatText,
+			wantStdout: []string{"Contributors:     342\n"},
+		},
+		{
+			name: "human-readable with forks and watchers",
+			info: PackageInfo{
+				Name:          "lodash",
+				Version:       "4.17.21",
+				Licenses:      []string{"MIT"},
+				Ecosystem:     "npm",
+				ForksCount:    &forksCount,
+				WatchersCount: &watchersCount,
+			},
+			format:     formatText,
+			wantStdout: []string{"Forks:            7000\n", "Watchers:         880\n"},
+		},
+		{
+			name: "human-readable with last commit date",
+			info: PackageInfo{
+				Name:           "lodash",
+				Version:        "4.17.21",
+				Licenses:       []string{"MIT"},
+				Ecosystem:      "npm",
+				LastCommitDate: &lastCommitDate,
+			},
+			format:     formatText,
+			wantStdout: []string{"Last Commit:      2023-11-15\n"},
+		},
+		{
+			name: "human-readable with downloads and last published date",
+			info: PackageInfo{
+				Name:              "library/nginx",
+				Version:           "1.27",
+				Licenses:          []string{},
+				Ecosystem:         "docker",
+				DownloadCount:     &downloadCount,
+				LastPublishedDate: &lastPublishedDate,
+			},
+			format:     formatText,
+			wantStdout: []string{"Downloads:        1000000000\n", "Last Published:   2024-05-20\n"},
+		},
+		{
+			name: "human-readable with bug tracker",
+			info: PackageInfo{
+				Name:          "rails",
+				Version:       "7.1.3",
+				Licenses:      []string{"MIT"},
+				Ecosystem:     "gem",
+				BugTrackerURL: "https://github.com/rails/rails/issues",
+			},
+			format:     formatText,
+			wantStdout: []string{"Bug Tracker:      https://github.com/rails/rails/issues\n"},
+		},
+		{
+			name:       "fingerprint output",
+			info:       PackageInfo{Name: "lodash", Version: "4.17.21"},
+			format:     formatFingerprint,
+			wantStdout: []string{"SHA256:"},
+		},
+		{
+			name:    "unknown format",
+			info:    PackageInfo{Name: "lodash"},
+			format:  "xml",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Note: Cannot use t.Parallel() because test modifies global os.Stdout
-
-			// Capture stdout.
-			oldStdout := os.Stdout
-			r, w, _ := os.Pipe()
-			os.Stdout = w
-
-			err := printOutput(tt.info, tt.outputJSON)
-
-			_ = w.Close()
-			os.Stdout = oldStdout
+			t.Parallel()
 
+			var buf bytes.Buffer
+			err := printOutput(&buf, tt.info, tt.format)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("printOutput() error = nil, want error")
+				}
+				return
+			}
 			if err != nil {
 				t.Errorf("printOutput() unexpected error = %v", err)
 				return
 			}
 
-			var buf bytes.Buffer
-			_, _ = io.Copy(&buf, r)
 			output := buf.String()
 
 			// Check for expected strings in output
@@ -260,7 +732,7 @@ func TestPrintOutput(t *testing.T) {
 			}
 
 			// For JSON output, validate it's actually valid JSON
-			if tt.outputJSON {
+			if tt.format == formatJSON {
 				var result PackageInfo
 				if jsonErr := json.Unmarshal([]byte(output), &result); jsonErr != nil {
 					t.Errorf("printOutput() produced invalid JSON: %v\nOutput: %s", jsonErr, output)
@@ -270,26 +742,224 @@ func TestPrintOutput(t *testing.T) {
 	}
 }
 
-// TestRun_Version tests the run function with the --version flag.
-func TestRun_Version(t *testing.T) {
-	// Note: Cannot use t.Parallel() because run() modifies global flag.CommandLine
+// TestPrintHumanReadableOutput_Alignment tests that the values of all the fields of the text output start
+// at the same column.
+func TestPrintHumanReadableOutput_Alignment(t *testing.T) {
+	t.Parallel()
 
-	// Save and restore os.Args and flag.CommandLine
-	oldArgs := os.Args
-	oldCommandLine := flag.CommandLine
-	t.Cleanup(func() {
-		os.Args = oldArgs
-		flag.CommandLine = oldCommandLine
-	})
+	count := 1
+	score, date := 7.8, "2024-01-10"
+	published := time.Date(2024, time.May, 20, 0, 0, 0, 0, time.UTC)
+	info := PackageInfo{
+		Name:                  "lodash",
+		Version:               "4.17.21",
+		Ecosystem:             "npm",
+		OriginalPURL:          "pkg:npm/lodash",
+		ResolvedPURL:          "pkg:npm/lodash@4.17.21",
+		Licenses:              []string{"MIT"},
+		LicenseSPDXExpression: "MIT",
+		Description:           "Lodash modular utilities.",
+		Homepage:              "https://lodash.com",
+		RegistryURL:           "https://www.npmjs.com/package/lodash/v/4.17.21",
+		RepositoryURL:         "https://github.com/lodash/lodash",
+		DocumentationURL:      "https://lodash.com/docs",
+		BugTrackerURL:         "https://github.com/lodash/lodash/issues",
+		ChangelogURL:          "https://github.com/lodash/lodash/releases",
+		SecurityPolicyURL:     "https://github.com/lodash/lodash/security/policy",
+		DependencyCount:       &count,
+		ContributorCount:      &count,
+		LastCommitDate:        &published,
+		ForksCount:            &count,
+		WatchersCount:         &count,
+		DownloadCount:         &count,
+		LastPublishedDate:     &published,
+		ScorecardScore:        &score,
+		ScorecardDate:         &date,
+	}
 
-	// Reset flag.CommandLine for this test
-	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
-	os.Args = []string{"purlinfo", "--version"}
+	var buf bytes.Buffer
+	if err := printHumanReadableOutput(&buf, info); err != nil {
+		t.Fatalf("printHumanReadableOutput() unexpected error = %v", err)
+	}
 
-	// Capture stdout
-	oldStdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
+	for line := range strings.Lines(buf.String()) {
+		label, _, _ := strings.Cut(line, ":")
+		if got := len(line) - len(strings.TrimLeft(line[len(label)+1:], " ")); got != labelColumnWidth {
+			t.Errorf("value of %q starts at column %d, want %d", label, got, labelColumnWidth)
+		}
+	}
+}
+
+// TestPrintOptionalField tests the exact output of the printOptionalField function.
+func TestPrintOptionalField(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		label string
+		value string
+		want  string
+	}{
+		{
+			name:  "short label with value",
+			label: "Homepage:",
+			value: "https://lodash.com",
+			want:  "Homepage:         https://lodash.com\n",
+		},
+		{
+			name:  "short label without value",
+			label: "Homepage:",
+			value: "",
+			want:  "Homepage:         (none)\n",
+		},
+		{
+			name:  "longest label with value",
+			label: "DocumentationURL:",
+			value: "https://lodash.com/docs",
+			want:  "DocumentationURL: https://lodash.com/docs\n",
+		},
+		{
+			name:  "longest label without value",
+			label: "DocumentationURL:",
+			value: "",
+			want:  "DocumentationURL: (none)\n",
+		},
+		{
+			name:  "label as wide as the column",
+			label: "Last Published At:",
+			value: "2024-05-20",
+			want:  "Last Published At: 2024-05-20\n",
+		},
+		{
+			name:  "label wider than the column with value",
+			label: "A Very Long Field Label:",
+			value: "value",
+			want:  "A Very Long Field Label: value\n",
+		},
+		{
+			name:  "label wider than the column without value",
+			label: "A Very Long Field Label:",
+			value: "",
+			want:  "A Very Long Field Label: (none)\n",
+		},
+		{
+			name:  "empty label",
+			label: "",
+			value: "value",
+			want:  "                  value\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			printOptionalField(&buf, tt.label, tt.value)
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("printOptionalField(%q, %q) = %q, want %q", tt.label, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPrintVersion tests the printVersion function.
+func TestPrintVersion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("text", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		if err := printVersion(&buf, false); err != nil {
+			t.Fatalf("printVersion() unexpected error = %v", err)
+		}
+		if want := "purlinfo version " + version + "\n"; buf.String() != want {
+			t.Errorf("printVersion() = %q, want %q", buf.String(), want)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		if err := printVersion(&buf, true); err != nil {
+			t.Fatalf("printVersion() unexpected error = %v", err)
+		}
+
+		var got versionInfo
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("printVersion() produced invalid JSON: %v\nOutput: %s", err, buf.String())
+		}
+		if got.Name != "purlinfo" || got.Version != version {
+			t.Errorf("printVersion() name, version = %q, %q, want purlinfo, %q", got.Name, got.Version, version)
+		}
+		if got.GoVersion != runtime.Version() {
+			t.Errorf("printVersion() go_version = %q, want %q", got.GoVersion, runtime.Version())
+		}
+		if got.BuildTime != "" {
+			if _, err := time.Parse(time.RFC3339, got.BuildTime); err != nil {
+				t.Errorf("printVersion() build_time = %q, want RFC 3339: %v", got.BuildTime, err)
+			}
+		}
+	})
+}
+
+// TestOutputFormat tests the outputFormat function.
+func TestOutputFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opts    cliOptions
+		want    string
+		wantErr bool
+	}{
+		{name: "text", opts: cliOptions{format: formatText}, want: formatText},
+		{name: "json", opts: cliOptions{format: formatJSON}, want: formatJSON},
+		{name: "fingerprint", opts: cliOptions{format: formatFingerprint}, want: formatFingerprint},
+		{name: "toml", opts: cliOptions{format: formatTOML}, want: formatTOML},
+		{name: "json flag", opts: cliOptions{format: formatText, outputJSON: true}, want: formatJSON},
+		{name: "fingerprint flag", opts: cliOptions{format: formatText, fingerprint: true}, want: formatFingerprint},
+		{name: "unknown format", opts: cliOptions{format: "xml"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := outputFormat(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("outputFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("outputFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRun_Version tests the run function with the --version flag.
+func TestRun_Version(t *testing.T) {
+	// Note: Cannot use t.Parallel() because run() modifies global flag.CommandLine
+
+	// Save and restore os.Args and flag.CommandLine
+	oldArgs := os.Args
+	oldCommandLine := flag.CommandLine
+	t.Cleanup(func() {
+		os.Args = oldArgs
+		flag.CommandLine = oldCommandLine
+	})
+
+	// Reset flag.CommandLine for this test
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	os.Args = []string{"purlinfo", "--version"}
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
 
 	exitCode := run()
 
@@ -429,9 +1099,112 @@ func TestRun_TooManyArguments(t *testing.T) {
 	}
 }
 
+// newTestRunConfig returns a RunConfig writing to buffers, for testing the commands.
+func newTestRunConfig(verbose bool, format string) (RunConfig, *bytes.Buffer, *bytes.Buffer) {
+	var stdout, stderr bytes.Buffer
+	logger, _ := setupLogger(verbose, logFormatText, &stderr)
+	return RunConfig{
+		Stdin:   strings.NewReader(""),
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+		Logger:  logger,
+		Verbose: verbose,
+		Format:  format,
+		Timeout: 30 * time.Second,
+		Keyring: &fakeKeyring{},
+	}, &stdout, &stderr
+}
+
+// TestWarnIfNotCanonical tests the warnIfNotCanonical function.
+func TestWarnIfNotCanonical(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		purl     string
+		wantWarn string
+	}{
+		{name: "canonical", purl: "pkg:npm/lodash@4.17.21", wantWarn: ""},
+		{
+			name:     "uppercase type",
+			purl:     "pkg:NPM/lodash@4.17.21",
+			wantWarn: "Warning: input purl is not canonical. Canonical form: pkg:npm/lodash@4.17.21\n",
+		},
+		{
+			name:     "unencoded namespace",
+			purl:     "pkg:npm/@angular/core@1.0.0",
+			wantWarn: "Warning: input purl is not canonical. Canonical form: pkg:npm/%40angular/core@1.0.0\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			var buf bytes.Buffer
+			warnIfNotCanonical(&buf, tt.purl, purl)
+
+			if got := buf.String(); got != tt.wantWarn {
+				t.Errorf("warnIfNotCanonical() wrote %q, want %q", got, tt.wantWarn)
+			}
+		})
+	}
+}
+
+// TestRunCommand_Stderr tests that runCommand writes errors and warnings to the configured Stderr.
+func TestRunCommand_Stderr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		purl       string
+		want       int
+		wantStderr string
+	}{
+		{
+			name:       "canonical warning",
+			purl:       "pkg:NPM/lodash@4.17.21",
+			want:       exitSuccess,
+			wantStderr: "Warning: input purl is not canonical. Canonical form: pkg:npm/lodash@4.17.21\n",
+		},
+		{
+			name:       "invalid purl",
+			purl:       "not-a-valid-purl",
+			want:       exitInvalidPurl,
+			wantStderr: "Error: Invalid purl format: ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, _, stderr := newTestRunConfig(false, formatText)
+			opts := cliOptions{
+				backend:     backendEcosystems,
+				timeout:     time.Second,
+				verifyCanon: true,
+				dryRun:      true,
+			}
+
+			if exitCode := runCommand(cfg, opts, "AND", []string{tt.purl}); exitCode != tt.want {
+				t.Errorf("runCommand() = %d, want %d\nStderr: %s", exitCode, tt.want, stderr.String())
+			}
+			if !strings.HasPrefix(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want prefix %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
 // TestRunWithService_Success tests the runWithService function with a successful mock service.
 func TestRunWithService_Success(t *testing.T) {
-	// Note: Cannot use t.Parallel() because test modifies global os.Stdout
+	t.Parallel()
 
 	// Create mock service that returns success.
 	mockSvc := &mockService{
@@ -454,19 +1227,10 @@ func TestRunWithService_Success(t *testing.T) {
 		t.Fatalf("failed to parse purl: %v", err)
 	}
 
-	// Setup logger.
-	logger := setupLogger(false)
-
-	// Capture stdout.
-	oldStdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
+	cfg, stdout, _ := newTestRunConfig(false, formatText)
 
 	// Call runWithService with mock.
-	exitCode := runWithService(mockSvc, logger, purl, "pkg:npm/test@1.0.0", false, false, 30*time.Second)
-
-	_ = w.Close()
-	os.Stdout = oldStdout
+	exitCode := runWithService(cfg, mockSvc, purl, "pkg:npm/test@1.0.0", "AND")
 
 	// Verify exit code.
 	if exitCode != exitSuccess {
@@ -474,11 +1238,11 @@ func TestRunWithService_Success(t *testing.T) {
 	}
 
 	// Verify output.
-	var buf bytes.Buffer
-	_, _ = io.Copy(&buf, r)
-	output := buf.String()
-
-	expectedStrings := []string{"test-package", "1.0.0", "MIT"}
+	output := stdout.String()
+	expectedStrings := []string{
+		"test-package", "1.0.0", "MIT", "SPDX Expression:  MIT",
+		"Registry:         https://www.npmjs.com/package/test/v/1.0.0",
+	}
 	for _, expected := range expectedStrings {
 		if !strings.Contains(output, expected) {
 			t.Errorf("output missing %q\nGot: %s", expected, output)
@@ -488,7 +1252,7 @@ func TestRunWithService_Success(t *testing.T) {
 
 // TestRunWithService_JSONOutput tests the runWithService function with JSON output.
 func TestRunWithService_JSONOutput(t *testing.T) {
-	// Note: Cannot use t.Parallel() because test modifies global os.Stdout
+	t.Parallel()
 
 	// Create mock service.
 	mockSvc := &mockService{
@@ -506,41 +1270,99 @@ func TestRunWithService_JSONOutput(t *testing.T) {
 	}
 
 	purl, _ := packageurl.FromString("pkg:npm/test@2.0.0")
-	logger := setupLogger(false)
-
-	// Capture stdout.
-	oldStdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
+	cfg, stdout, _ := newTestRunConfig(false, formatJSON)
 
 	// Call with JSON output enabled.
-	exitCode := runWithService(mockSvc, logger, purl, "pkg:npm/test@2.0.0", false, true, 30*time.Second)
-
-	_ = w.Close()
-	os.Stdout = oldStdout
+	exitCode := runWithService(cfg, mockSvc, purl, "pkg:npm/test@2.0.0", "AND")
 
 	if exitCode != exitSuccess {
 		t.Errorf("runWithService() = %d, want %d", exitCode, exitSuccess)
 	}
 
 	// Verify JSON output.
-	var buf bytes.Buffer
-	_, _ = io.Copy(&buf, r)
-	output := buf.String()
-
 	var result PackageInfo
-	if jsonErr := json.Unmarshal([]byte(output), &result); jsonErr != nil {
-		t.Errorf("runWithService() produced invalid JSON: %v\nOutput: %s", jsonErr, output)
+	if jsonErr := json.Unmarshal(stdout.Bytes(), &result); jsonErr != nil {
+		t.Errorf("runWithService() produced invalid JSON: %v\nOutput: %s", jsonErr, stdout.String())
 	}
 
 	if result.Name != "json-test" || result.Version != "2.0.0" {
 		t.Errorf("runWithService() JSON = %+v, want name=json-test version=2.0.0", result)
 	}
+	if result.OriginalPURL != "pkg:npm/test@2.0.0" {
+		t.Errorf("runWithService() JSON original_purl = %q, want %q", result.OriginalPURL, "pkg:npm/test@2.0.0")
+	}
+	if result.LicenseSPDXExpression != "Apache-2.0 AND MIT" {
+		t.Errorf("runWithService() JSON license_spdx_expression = %q, want %q",
+			result.LicenseSPDXExpression, "Apache-2.0 AND MIT")
+	}
+	if result.RegistryURL != "https://www.npmjs.com/package/test/v/2.0.0" {
+		t.Errorf("runWithService() JSON registry_url = %q, want %q",
+			result.RegistryURL, "https://www.npmjs.com/package/test/v/2.0.0")
+	}
+}
+
+// TestRunWithService_WrapJSON tests the runWithService function with the JSON output wrapped.
+func TestRunWithService_WrapJSON(t *testing.T) {
+	t.Parallel()
+
+	mockSvc := &mockService{info: PackageInfo{Name: "lodash", Version: "4.17.21", Ecosystem: "npm"}}
+
+	purl, _ := packageurl.FromString("pkg:npm/lodash@4.17.21")
+	cfg, stdout, _ := newTestRunConfig(false, formatJSON)
+	cfg.WrapJSON = true
+
+	before := time.Now().UTC().Truncate(time.Second)
+	exitCode := runWithService(cfg, mockSvc, purl, "pkg:npm/lodash@4.17.21", "AND")
+	after := time.Now().UTC()
+
+	if exitCode != exitSuccess {
+		t.Errorf("runWithService() = %d, want %d", exitCode, exitSuccess)
+	}
+
+	var result struct {
+		FetchedAt string      `json:"fetched_at"`
+		Data      PackageInfo `json:"data"`
+	}
+	if jsonErr := json.Unmarshal(stdout.Bytes(), &result); jsonErr != nil {
+		t.Fatalf("runWithService() produced invalid JSON: %v\nOutput: %s", jsonErr, stdout.String())
+	}
+
+	if !strings.HasSuffix(result.FetchedAt, "Z") {
+		t.Errorf("runWithService() JSON fetched_at = %q, want a UTC time", result.FetchedAt)
+	}
+	fetchedAt, err := time.Parse(time.RFC3339, result.FetchedAt)
+	if err != nil {
+		t.Fatalf("runWithService() JSON fetched_at = %q, want RFC 3339: %v", result.FetchedAt, err)
+	}
+	if fetchedAt.Before(before) || fetchedAt.After(after) {
+		t.Errorf("runWithService() JSON fetched_at = %v, want between %v and %v", fetchedAt, before, after)
+	}
+	if result.Data.Name != "lodash" || result.Data.OriginalPURL != "pkg:npm/lodash@4.17.21" {
+		t.Errorf("runWithService() JSON data = %+v, want the package info", result.Data)
+	}
+}
+
+// TestRunWithService_WrapJSONText tests that -wrap-json does not change the text output.
+func TestRunWithService_WrapJSONText(t *testing.T) {
+	t.Parallel()
+
+	mockSvc := &mockService{info: PackageInfo{Name: "lodash", Version: "4.17.21", Ecosystem: "npm"}}
+
+	purl, _ := packageurl.FromString("pkg:npm/lodash@4.17.21")
+	cfg, stdout, _ := newTestRunConfig(false, formatText)
+	cfg.WrapJSON = true
+
+	if exitCode := runWithService(cfg, mockSvc, purl, "pkg:npm/lodash@4.17.21", "AND"); exitCode != exitSuccess {
+		t.Errorf("runWithService() = %d, want %d", exitCode, exitSuccess)
+	}
+	if strings.Contains(stdout.String(), "fetched_at") || !strings.Contains(stdout.String(), "Name:") {
+		t.Errorf("runWithService() output = %q, want the text output", stdout.String())
+	}
 }
 
 // TestRunWithService_ServiceError tests the runWithService function when service returns an error.
 func TestRunWithService_ServiceError(t *testing.T) {
-	// Note: Cannot use t.Parallel() because test modifies global os.Stderr
+	t.Parallel()
 
 	// Create mock service that returns error.
 	mockSvc := &mockService{
@@ -548,17 +1370,9 @@ func TestRunWithService_ServiceError(t *testing.T) {
 	}
 
 	purl, _ := packageurl.FromString("pkg:npm/test@1.0.0")
-	logger := setupLogger(false)
+	cfg, stdout, stderr := newTestRunConfig(false, formatText)
 
-	// Capture stderr.
-	oldStderr := os.Stderr
-	r, w, _ := os.Pipe()
-	os.Stderr = w
-
-	exitCode := runWithService(mockSvc, logger, purl, "pkg:npm/test@1.0.0", false, false, 30*time.Second)
-
-	_ = w.Close()
-	os.Stderr = oldStderr
+	exitCode := runWithService(cfg, mockSvc, purl, "pkg:npm/test@1.0.0", "AND")
 
 	// Verify exit code.
 	if exitCode != exitRuntimeError {
@@ -566,18 +1380,17 @@ func TestRunWithService_ServiceError(t *testing.T) {
 	}
 
 	// Verify error message in stderr.
-	var buf bytes.Buffer
-	_, _ = io.Copy(&buf, r)
-	output := buf.String()
-
-	if !strings.Contains(output, "Failed to get package info") {
+	if output := stderr.String(); !strings.Contains(output, "Failed to get package info") {
 		t.Errorf("output missing error message\nGot: %s", output)
 	}
+	if stdout.Len() != 0 {
+		t.Errorf("runWithService() wrote to stdout on error: %q", stdout.String())
+	}
 }
 
 // TestRunWithService_ServiceErrorVerbose tests error output in verbose mode.
 func TestRunWithService_ServiceErrorVerbose(t *testing.T) {
-	// Note: Cannot use t.Parallel() because test modifies global os.Stderr
+	t.Parallel()
 
 	// Create mock service with specific error.
 	mockSvc := &mockService{
@@ -585,29 +1398,462 @@ func TestRunWithService_ServiceErrorVerbose(t *testing.T) {
 	}
 
 	purl, _ := packageurl.FromString("pkg:npm/test@1.0.0")
-	logger := setupLogger(true)
-
-	// Capture stderr.
-	oldStderr := os.Stderr
-	r, w, _ := os.Pipe()
-	os.Stderr = w
+	cfg, _, stderr := newTestRunConfig(true, formatText)
 
 	// Call with verbose=true.
-	exitCode := runWithService(mockSvc, logger, purl, "pkg:npm/test@1.0.0", true, false, 30*time.Second)
-
-	_ = w.Close()
-	os.Stderr = oldStderr
+	exitCode := runWithService(cfg, mockSvc, purl, "pkg:npm/test@1.0.0", "AND")
 
 	if exitCode != exitRuntimeError {
 		t.Errorf("runWithService() = %d, want %d", exitCode, exitRuntimeError)
 	}
 
 	// In verbose mode, should include the actual error.
-	var buf bytes.Buffer
-	_, _ = io.Copy(&buf, r)
-	output := buf.String()
-
-	if !strings.Contains(output, "specific error message") {
+	if output := stderr.String(); !strings.Contains(output, "specific error message") {
 		t.Errorf("verbose output missing specific error\nGot: %s", output)
 	}
 }
+
+// TestRunWithService_ExitCodes tests the exit code for each kind of service error.
+func TestRunWithService_ExitCodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{
+			name: "not found",
+			err:  fmt.Errorf("%w: HTTP 404", ErrPackageNotFound),
+			want: exitNotFound,
+		},
+		{
+			name: "rate limited",
+			err:  statusError(http.StatusTooManyRequests),
+			want: exitRateLimited,
+		},
+		{
+			name: "API error",
+			err:  statusError(http.StatusInternalServerError),
+			want: exitAPIError,
+		},
+		{
+			name: "invalid response",
+			err:  fmt.Errorf("%w: unexpected EOF", ErrInvalidResponse),
+			want: exitAPIError,
+		},
+		{
+			name: "unsupported ecosystem",
+			err:  fmt.Errorf("%w: npm", ErrUnsupportedEcosystem),
+			want: exitInvalidArgs,
+		},
+		{
+			name: "invalid purl",
+			err:  errMissingGroupID,
+			want: exitInvalidPurl,
+		},
+		{
+			name: "network error",
+			err: fmt.Errorf("failed to make HTTP request: %w", &url.Error{
+				Op:  "Get",
+				URL: "https://packages.ecosyste.ms",
+				Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			}),
+			want: exitNetworkError,
+		},
+		{
+			name: "timeout",
+			err:  fmt.Errorf("interrupted while waiting to retry: %w", context.DeadlineExceeded),
+			want: exitNetworkError,
+		},
+		{
+			name: "unexpected error",
+			err:  errors.New("something went wrong"),
+			want: exitRuntimeError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := &mockService{err: tt.err}
+			purl, _ := packageurl.FromString("pkg:npm/test@1.0.0")
+			cfg, _, _ := newTestRunConfig(false, formatText)
+
+			if exitCode := runWithService(cfg, mockSvc, purl, "pkg:npm/test@1.0.0", "AND"); exitCode != tt.want {
+				t.Errorf("runWithService() = %d, want %d", exitCode, tt.want)
+			}
+		})
+	}
+}
+
+// TestRunListVersions tests the runListVersions function.
+func TestRunListVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		service    Service
+		format     string
+		want       int
+		wantStdout string
+	}{
+		{
+			name:       "plain output",
+			service:    &mockVersionLister{versions: []string{"1.0.0", "1.1.0"}},
+			format:     formatText,
+			want:       exitSuccess,
+			wantStdout: "1.0.0\n1.1.0\n",
+		},
+		{
+			name:       "JSON output",
+			service:    &mockVersionLister{versions: []string{"1.0.0", "1.1.0"}},
+			format:     formatJSON,
+			want:       exitSuccess,
+			wantStdout: "[\n  \"1.0.0\",\n  \"1.1.0\"\n]\n",
+		},
+		{
+			name:    "service error",
+			service: &mockVersionLister{mockService: mockService{err: ErrPackageNotFound}},
+			format:  formatText,
+			want:    exitNotFound,
+		},
+		{
+			name:    "unsupported service",
+			service: &mockService{},
+			format:  formatText,
+			want:    exitInvalidArgs,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			purl, _ := packageurl.FromString("pkg:npm/test")
+			cfg, stdout, _ := newTestRunConfig(false, tt.format)
+
+			exitCode := runListVersions(cfg, tt.service, purl)
+
+			if exitCode != tt.want {
+				t.Errorf("runListVersions() = %d, want %d", exitCode, tt.want)
+			}
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("runListVersions() output = %q, want %q", stdout.String(), tt.wantStdout)
+			}
+		})
+	}
+}
+
+// TestNormalizePURL tests the normalizePURL function.
+func TestNormalizePURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		purl string
+		want string
+	}{
+		{purl: "pkg:pypi/requests@2.32.5", want: "pkg:pypi/requests@2.32.5"},
+		{purl: "pkg:pypi/Django_REST@3.14.0", want: "pkg:pypi/django-rest@3.14.0"},
+		{purl: "pkg:pypi/zope.interface", want: "pkg:pypi/zope-interface"},
+		{purl: "pkg:pypi/Foo__Bar.-baz", want: "pkg:pypi/foo-bar-baz"},
+		{purl: "pkg:npm/lodash.merge@4.6.2", want: "pkg:npm/lodash.merge@4.6.2"},
+		{purl: "pkg:maven/org.apache.commons/commons-lang3", want: "pkg:maven/org.apache.commons/commons-lang3"},
+	}
+
+	logger := slog.New(slog.DiscardHandler)
+
+	for _, tt := range tests {
+		t.Run(tt.purl, func(t *testing.T) {
+			t.Parallel()
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			if got := normalizePURL(logger, purl); got.String() != tt.want {
+				t.Errorf("normalizePURL(%q) = %q, want %q", tt.purl, got.String(), tt.want)
+			}
+		})
+	}
+}
+
+// TestLookupPURL tests the lookupPURL function.
+func TestLookupPURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		purl string
+		want string
+	}{
+		{purl: "pkg:npm/lodash@4.17.21", want: "pkg:npm/lodash@4.17.21"},
+		{purl: "pkg:npm/lodash", want: "pkg:npm/lodash"},
+		{purl: "pkg:npm/lodash@latest", want: "pkg:npm/lodash"},
+		{purl: "pkg:npm/lodash@LATEST", want: "pkg:npm/lodash"},
+		{purl: "pkg:maven/org.apache.commons/commons-lang3@latest?type=jar",
+			want: "pkg:maven/org.apache.commons/commons-lang3?type=jar"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.purl, func(t *testing.T) {
+			t.Parallel()
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+			if got := lookupPURL(purl); got.String() != tt.want {
+				t.Errorf("lookupPURL(%q) = %q, want %q", tt.purl, got.String(), tt.want)
+			}
+		})
+	}
+}
+
+// TestCompletePackageInfo tests the completePackageInfo function.
+func TestCompletePackageInfo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		purl             string
+		info             PackageInfo
+		wantResolvedPURL string
+		wantRegistryURL  string
+		wantChangelogURL string
+		wantSecurityURL  string
+		wantSPDX         string
+	}{
+		{
+			name:            "versioned purl",
+			purl:            "pkg:npm/lodash@4.17.20",
+			info:            PackageInfo{Name: "lodash", Version: "4.17.20", Licenses: []string{"MIT"}},
+			wantRegistryURL: "https://www.npmjs.com/package/lodash/v/4.17.20",
+			wantSPDX:        "MIT",
+		},
+		{
+			name: "non-SPDX licenses",
+			purl: "pkg:cran/A3@1.0.0",
+			info: PackageInfo{
+				Name:            "A3",
+				Version:         "1.0.0",
+				Licenses:        []string{"GPL (>= 2)"},
+				NonSPDXLicenses: true,
+			},
+		},
+		{
+			name:             "purl without version",
+			purl:             "pkg:npm/lodash",
+			info:             PackageInfo{Name: "lodash", Version: "4.17.21"},
+			wantResolvedPURL: "pkg:npm/lodash@4.17.21",
+			wantRegistryURL:  "https://www.npmjs.com/package/lodash/v/4.17.21",
+		},
+		{
+			name:             "latest version",
+			purl:             "pkg:npm/%40types/node@latest",
+			info:             PackageInfo{Name: "@types/node", Version: "22.0.0"},
+			wantResolvedPURL: "pkg:npm/%40types/node@22.0.0",
+			wantRegistryURL:  "https://www.npmjs.com/package/@types/node/v/22.0.0",
+		},
+		{
+			name:            "package without releases",
+			purl:            "pkg:npm/lodash",
+			info:            PackageInfo{Name: "lodash"},
+			wantRegistryURL: "https://www.npmjs.com/package/lodash",
+		},
+		{
+			name:            "latest version without version from the service",
+			purl:            "pkg:npm/lodash@latest",
+			info:            PackageInfo{Name: "lodash"},
+			wantRegistryURL: "https://www.npmjs.com/package/lodash",
+		},
+		{
+			name: "private registry page from the service",
+			purl: "pkg:npm/%40octo-org/hello-world@1.0.0",
+			info: PackageInfo{
+				Name:            "hello-world",
+				Version:         "1.0.0",
+				RegistryURL:     "https://github.com/octo-org/hello-world/packages/1",
+				PrivateRegistry: true,
+			},
+			wantRegistryURL: "https://github.com/octo-org/hello-world/packages/1",
+		},
+		{
+			name:            "private registry without page",
+			purl:            "pkg:gem/octo-gem@0.1.0",
+			info:            PackageInfo{Name: "octo-gem", Version: "0.1.0", PrivateRegistry: true},
+			wantRegistryURL: "",
+		},
+		{
+			name: "changelog from the GitHub repository",
+			purl: "pkg:npm/lodash@4.17.21",
+			info: PackageInfo{
+				Name:          "lodash",
+				Version:       "4.17.21",
+				RepositoryURL: "git+https://github.com/lodash/lodash.git",
+			},
+			wantRegistryURL:  "https://www.npmjs.com/package/lodash/v/4.17.21",
+			wantChangelogURL: "https://github.com/lodash/lodash/releases",
+			wantSecurityURL:  "https://github.com/lodash/lodash/security/policy",
+		},
+		{
+			name: "changelog from the service",
+			purl: "pkg:gem/rails@7.1.3",
+			info: PackageInfo{
+				Name:          "rails",
+				Version:       "7.1.3",
+				RepositoryURL: "https://github.com/rails/rails",
+				ChangelogURL:  "https://github.com/rails/rails/blob/v7.1.3/CHANGELOG.md",
+			},
+			wantRegistryURL:  "https://rubygems.org/gems/rails/versions/7.1.3",
+			wantChangelogURL: "https://github.com/rails/rails/blob/v7.1.3/CHANGELOG.md",
+			wantSecurityURL:  "https://github.com/rails/rails/security/policy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got := completePackageInfo(tt.info, purl, tt.purl, "AND")
+			if got.OriginalPURL != tt.purl {
+				t.Errorf("completePackageInfo() OriginalPURL = %q, want %q", got.OriginalPURL, tt.purl)
+			}
+			if got.Licenses == nil {
+				t.Error("completePackageInfo() Licenses is nil, want non-nil slice")
+			}
+			if got.ResolvedPURL != tt.wantResolvedPURL {
+				t.Errorf("completePackageInfo() ResolvedPURL = %q, want %q", got.ResolvedPURL, tt.wantResolvedPURL)
+			}
+			if got.RegistryURL != tt.wantRegistryURL {
+				t.Errorf("completePackageInfo() RegistryURL = %q, want %q", got.RegistryURL, tt.wantRegistryURL)
+			}
+			if got.ChangelogURL != tt.wantChangelogURL {
+				t.Errorf("completePackageInfo() ChangelogURL = %q, want %q", got.ChangelogURL, tt.wantChangelogURL)
+			}
+			if got.SecurityPolicyURL != tt.wantSecurityURL {
+				t.Errorf("completePackageInfo() SecurityPolicyURL = %q, want %q", got.SecurityPolicyURL, tt.wantSecurityURL)
+			}
+			if got.LicenseSPDXExpression != tt.wantSPDX {
+				t.Errorf("completePackageInfo() LicenseSPDXExpression = %q, want %q", got.LicenseSPDXExpression, tt.wantSPDX)
+			}
+		})
+	}
+}
+
+// TestRunWithService_ResolvedPURL tests that the text output shows the resolved purl.
+func TestRunWithService_ResolvedPURL(t *testing.T) {
+	t.Parallel()
+
+	mockSvc := &mockService{info: PackageInfo{Name: "lodash", Version: "4.17.21", Ecosystem: "npm"}}
+
+	purl, _ := packageurl.FromString("pkg:npm/lodash@latest")
+	cfg, stdout, _ := newTestRunConfig(false, formatText)
+
+	if exitCode := runWithService(cfg, mockSvc, purl, "pkg:npm/lodash@latest", "AND"); exitCode != exitSuccess {
+		t.Errorf("runWithService() = %d, want %d", exitCode, exitSuccess)
+	}
+
+	wantStdout := []string{
+		"PURL:             pkg:npm/lodash@latest\n",
+		"Resolved PURL:    pkg:npm/lodash@4.17.21\n",
+	}
+	for _, want := range wantStdout {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("runWithService() output missing %q\nGot: %s", want, stdout.String())
+		}
+	}
+}
+
+// TestRunAllResults tests the runAllResults function.
+func TestRunAllResults(t *testing.T) {
+	t.Parallel()
+
+	matches := []PackageInfo{
+		{Name: "lodash", Version: "4.17.21", Licenses: []string{"MIT"}, Ecosystem: "npm"},
+		{Name: "lodash", Version: "4.17.20", Licenses: []string{"MIT", "Apache-2.0"}, Ecosystem: "npm"},
+	}
+
+	tests := []struct {
+		name       string
+		service    Service
+		format     string
+		wrapJSON   bool
+		want       int
+		wantStdout []string
+	}{
+		{
+			name:       "text output",
+			service:    &mockMatchLister{matches: matches},
+			format:     formatText,
+			want:       exitSuccess,
+			wantStdout: []string{"Version:          4.17.21\n", "\n\nName:", "Version:          4.17.20\n"},
+		},
+		{
+			name:    "JSON output",
+			service: &mockMatchLister{matches: matches},
+			format:  formatJSON,
+			want:    exitSuccess,
+			wantStdout: []string{
+				`"original_purl": "pkg:npm/lodash"`,
+				`"license_spdx_expression": "MIT AND Apache-2.0"`,
+			},
+		},
+		{
+			name:     "wrapped JSON output",
+			service:  &mockMatchLister{matches: matches},
+			format:   formatJSON,
+			wrapJSON: true,
+			want:     exitSuccess,
+			wantStdout: []string{
+				`"fetched_at": "`,
+				`"data": [`,
+			},
+		},
+		{
+			name:    "service error",
+			service: &mockMatchLister{mockService: mockService{err: ErrPackageNotFound}},
+			format:  formatText,
+			want:    exitNotFound,
+		},
+		{
+			name:    "unsupported service",
+			service: &mockService{},
+			format:  formatText,
+			want:    exitInvalidArgs,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			purl, _ := packageurl.FromString("pkg:npm/lodash")
+			cfg, stdout, _ := newTestRunConfig(false, tt.format)
+			cfg.WrapJSON = tt.wrapJSON
+
+			exitCode := runAllResults(cfg, tt.service, purl, "pkg:npm/lodash", "AND")
+
+			if exitCode != tt.want {
+				t.Errorf("runAllResults() = %d, want %d", exitCode, tt.want)
+			}
+			for _, want := range tt.wantStdout {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("runAllResults() output missing %q\nGot: %s", want, stdout.String())
+				}
+			}
+			if tt.format == formatJSON && !tt.wrapJSON && tt.want == exitSuccess {
+				var got []PackageInfo
+				if err := json.Unmarshal(stdout.Bytes(), &got); err != nil || len(got) != len(matches) {
+					t.Errorf("runAllResults() JSON output = %s, want array of %d packages", stdout.String(), len(matches))
+				}
+			}
+		})
+	}
+}
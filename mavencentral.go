@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+const (
+	// mavenCentralBaseURL is the base URL for the Sonatype Maven Central search API.
+	//
+	// See https://central.sonatype.org/search/rest-api-guide/
+	mavenCentralBaseURL = "https://search.maven.org"
+	// mavenCentralSearchAPIPath is the API path for searching artifacts.
+	mavenCentralSearchAPIPath = "/solrsearch/select"
+)
+
+// errMissingGroupID is returned when a Maven purl has no namespace.
+var errMissingGroupID = fmt.Errorf("%w: maven purl requires a namespace (groupId)", ErrInvalidPURL)
+
+// MavenCentralService is the service for the Sonatype Maven Central search API.
+type MavenCentralService struct {
+	baseURL string
+	client  *http.Client
+}
+
+var _ Service = (*MavenCentralService)(nil)
+
+// MavenCentralServiceOptions are the options for the MavenCentralService.
+type MavenCentralServiceOptions struct {
+	// BaseURL is the base URL for the Maven Central search API.
+	// If empty, defaults to the public Maven Central search API.
+	BaseURL string
+	// Client is the HTTP client to use for the Maven Central search API.
+	// If nil, defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// NewMavenCentralService creates a new MavenCentralService.
+func NewMavenCentralService(opts MavenCentralServiceOptions) *MavenCentralService {
+	// Default to the Maven Central search API base URL.
+	baseURL := mavenCentralBaseURL
+	if opts.BaseURL != "" {
+		baseURL = opts.BaseURL
+	}
+	// Default to the default HTTP client.
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &MavenCentralService{
+		baseURL: baseURL,
+		client:  client,
+	}
+}
+
+// mavenCentralSearchResponse is the response from the Maven Central search API.
+type mavenCentralSearchResponse struct {
+	Response struct {
+		NumFound int `json:"numFound"`
+		Docs     []struct {
+			GroupID    string `json:"g"`
+			ArtifactID string `json:"a"`
+			Version    string `json:"v"`
+		} `json:"docs"`
+	} `json:"response"`
+}
+
+// mavenGroupID returns the Maven groupId from a purl namespace.
+//
+// The purl spec uses the dotted groupId as the namespace (`org.apache.commons`),
+// but purls are sometimes written with slashes (`org/apache/commons`).
+func mavenGroupID(namespace string) string {
+	return strings.ReplaceAll(namespace, "/", ".")
+}
+
+// solrPhrase quotes a Solr query term, so that characters with a meaning in the query syntax
+// (such as - or :) are matched literally.
+func solrPhrase(term string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(term) + `"`
+}
+
+// GetPackageInfo returns the information about a package.
+//
+// The search API does not expose licenses or project URLs. If the purl has no version,
+// the most recently published version is used.
+func (s *MavenCentralService) GetPackageInfo(ctx context.Context, purl packageurl.PackageURL) (PackageInfo, error) {
+	if purl.Type != packageurl.TypeMaven {
+		return PackageInfo{}, fmt.Errorf("%w: %s", ErrUnsupportedEcosystem, purl.Type)
+	}
+	if purl.Namespace == "" {
+		return PackageInfo{}, errMissingGroupID
+	}
+
+	query := fmt.Sprintf("g:%s AND a:%s", solrPhrase(mavenGroupID(purl.Namespace)), solrPhrase(purl.Name))
+	if purl.Version != "" {
+		query += " AND v:" + solrPhrase(purl.Version)
+	}
+
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("core", "gav")
+	params.Set("rows", "1")
+	params.Set("wt", "json")
+	apiURL := fmt.Sprintf("%s%s?%s", s.baseURL, mavenCentralSearchAPIPath, params.Encode())
+
+	var result mavenCentralSearchResponse
+	if err := getJSON(ctx, s.client, apiURL, nil, &result); err != nil {
+		return PackageInfo{}, err
+	}
+
+	if len(result.Response.Docs) == 0 {
+		return PackageInfo{}, fmt.Errorf("%w: %s", ErrPackageNotFound, purl.String())
+	}
+
+	doc := result.Response.Docs[0]
+
+	return PackageInfo{
+		Name:      doc.GroupID + ":" + doc.ArtifactID,
+		Version:   doc.Version,
+		Licenses:  []string{},
+		Ecosystem: purl.Type,
+	}, nil
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// TestNewMavenCentralService tests the NewMavenCentralService function.
+func TestNewMavenCentralService(t *testing.T) {
+	t.Parallel()
+
+	service := NewMavenCentralService(MavenCentralServiceOptions{})
+
+	if service.baseURL != mavenCentralBaseURL {
+		t.Errorf("baseURL = %q, want %q", service.baseURL, mavenCentralBaseURL)
+	}
+	if service.client != http.DefaultClient {
+		t.Error("client should be http.DefaultClient when not provided")
+	}
+}
+
+// TestMavenCentralService_GetPackageInfo tests the GetPackageInfo method.
+func TestMavenCentralService_GetPackageInfo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		mockResponse string
+		purl         string
+		wantQuery    string
+		want         PackageInfo
+		wantErr      error
+	}{
+		{
+			name: "versioned purl",
+			mockResponse: `{"response": {"numFound": 1, "docs": [
+				{"id": "org.apache.commons:commons-lang3:3.12.0", "g": "org.apache.commons",
+				 "a": "commons-lang3", "v": "3.12.0", "p": "jar"}
+			]}}`,
+			purl:      "pkg:maven/org.apache.commons/commons-lang3@3.12.0",
+			wantQuery: `g:"org.apache.commons" AND a:"commons-lang3" AND v:"3.12.0"`,
+			want: PackageInfo{
+				Name:      "org.apache.commons:commons-lang3",
+				Version:   "3.12.0",
+				Licenses:  []string{},
+				Ecosystem: "maven",
+			},
+		},
+		{
+			name: "slash separated namespace without version",
+			mockResponse: `{"response": {"numFound": 20, "docs": [
+				{"g": "org.apache.commons", "a": "commons-lang3", "v": "3.14.0"}
+			]}}`,
+			purl:      "pkg:maven/org/apache/commons/commons-lang3",
+			wantQuery: `g:"org.apache.commons" AND a:"commons-lang3"`,
+			want: PackageInfo{
+				Name:      "org.apache.commons:commons-lang3",
+				Version:   "3.14.0",
+				Licenses:  []string{},
+				Ecosystem: "maven",
+			},
+		},
+		{
+			name:         "no results",
+			mockResponse: `{"response": {"numFound": 0, "docs": []}}`,
+			purl:         "pkg:maven/com.example/missing@1.0.0",
+			wantQuery:    `g:"com.example" AND a:"missing" AND v:"1.0.0"`,
+			wantErr:      ErrPackageNotFound,
+		},
+		{
+			name:    "missing namespace",
+			purl:    "pkg:maven/commons-lang3@3.12.0",
+			wantErr: errMissingGroupID,
+		},
+		{
+			name:    "unsupported ecosystem",
+			purl:    "pkg:npm/lodash@4.17.21",
+			wantErr: ErrUnsupportedEcosystem,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != mavenCentralSearchAPIPath {
+					t.Errorf("request path = %q, want %q", r.URL.Path, mavenCentralSearchAPIPath)
+				}
+				query := r.URL.Query()
+				if query.Get("q") != tt.wantQuery {
+					t.Errorf("q = %q, want %q", query.Get("q"), tt.wantQuery)
+				}
+				if query.Get("core") != "gav" || query.Get("rows") != "1" || query.Get("wt") != "json" {
+					t.Errorf("unexpected query parameters: %s", r.URL.RawQuery)
+				}
+
+				_, _ = w.Write([]byte(tt.mockResponse))
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewMavenCentralService(MavenCentralServiceOptions{
+				BaseURL: server.URL,
+			})
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.GetPackageInfo(context.Background(), purl)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetPackageInfo() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+
+			if got.Name != tt.want.Name {
+				t.Errorf("GetPackageInfo() Name = %q, want %q", got.Name, tt.want.Name)
+			}
+			if got.Version != tt.want.Version {
+				t.Errorf("GetPackageInfo() Version = %q, want %q", got.Version, tt.want.Version)
+			}
+			if got.Ecosystem != tt.want.Ecosystem {
+				t.Errorf("GetPackageInfo() Ecosystem = %q, want %q", got.Ecosystem, tt.want.Ecosystem)
+			}
+			if !equalStringSlices(got.Licenses, tt.want.Licenses) {
+				t.Errorf("GetPackageInfo() Licenses = %v, want %v", got.Licenses, tt.want.Licenses)
+			}
+		})
+	}
+}
+
+// TestSolrPhrase tests the solrPhrase function.
+func TestSolrPhrase(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		term string
+		want string
+	}{
+		{term: "commons-lang3", want: `"commons-lang3"`},
+		{term: "1.0.0-RC1", want: `"1.0.0-RC1"`},
+		{term: `a"b\c`, want: `"a\"b\\c"`},
+	}
+
+	for _, tt := range tests {
+		if got := solrPhrase(tt.term); got != tt.want {
+			t.Errorf("solrPhrase(%q) = %s, want %s", tt.term, got, tt.want)
+		}
+	}
+}
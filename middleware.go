@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrDryRun is returned for requests that were printed by the DryRunMiddleware instead of being sent.
+var ErrDryRun = errors.New("dry run: request not sent")
+
+// maskedToken is the placeholder used in place of secrets in log output.
+const maskedToken = "****"
+
+// RoundTripperMiddleware wraps an http.RoundTripper with additional behavior.
+type RoundTripperMiddleware func(next http.RoundTripper) http.RoundTripper
+
+// roundTripperFunc adapts an ordinary function to the http.RoundTripper interface.
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// AuthMiddleware returns a middleware that sets an `Authorization: Bearer <token>` header
+// on the outbound requests to host.
+//
+// Requests to other hosts, such as redirects or pagination links leaving the API, are sent
+// without an Authorization header so the token is never leaked to a third party.
+func AuthMiddleware(token, host string) RoundTripperMiddleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if !strings.EqualFold(req.URL.Host, host) {
+				if req.Header.Get("Authorization") == "" {
+					return next.RoundTrip(req)
+				}
+				// A RoundTripper must not modify the request it was given.
+				anonReq := req.Clone(req.Context())
+				anonReq.Header.Del("Authorization")
+				return next.RoundTrip(anonReq)
+			}
+			authReq := req.Clone(req.Context())
+			authReq.Header.Set("Authorization", "Bearer "+token)
+			return next.RoundTrip(authReq)
+		})
+	}
+}
+
+// DryRunMiddleware returns a middleware that prints every outbound request to w instead of sending it.
+//
+// The request line is followed by the request headers, with the Authorization header masked.
+// The requests fail with ErrDryRun, so only the first request of a lookup is printed when the
+// following requests depend on its response.
+func DryRunMiddleware(w io.Writer) RoundTripperMiddleware {
+	return func(_ http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			fmt.Fprintf(w, "%s %s\n", req.Method, req.URL)
+			for _, name := range slices.Sorted(maps.Keys(req.Header)) {
+				value := strings.Join(req.Header.Values(name), ", ")
+				if name == "Authorization" {
+					value = maskedToken
+				}
+				fmt.Fprintf(w, "  %s: %s\n", name, value)
+			}
+			return nil, ErrDryRun
+		})
+	}
+}
+
+// RateLimitMiddleware returns a middleware that keeps the requests within the rate limit budget advertised
+// by the X-RateLimit-Remaining and X-RateLimit-Reset response headers.
+//
+// Once a response reports that no requests remain, the following requests wait until the reset time
+// instead of being rejected with HTTP 429. If the wait would exceed the context deadline, they fail with
+// ErrRateLimited without being sent. Each host has its own budget, since the services of a lookup can
+// query several APIs.
+func RateLimitMiddleware() RoundTripperMiddleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		var budgets rateLimitBudgets
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			budget := budgets.forHost(req.URL.Host)
+			if err := budget.wait(req); err != nil {
+				return nil, err
+			}
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+			budget.update(resp.Header, time.Now())
+			return resp, nil
+		})
+	}
+}
+
+// rateLimitBudgets holds the rate limit budget of each host.
+type rateLimitBudgets struct {
+	mu     sync.Mutex
+	byHost map[string]*rateLimitBudget
+}
+
+// forHost returns the budget of host, creating it on first use.
+func (b *rateLimitBudgets) forHost(host string) *rateLimitBudget {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.byHost == nil {
+		b.byHost = map[string]*rateLimitBudget{}
+	}
+	budget, ok := b.byHost[host]
+	if !ok {
+		budget = &rateLimitBudget{}
+		b.byHost[host] = budget
+	}
+	return budget
+}
+
+// rateLimitBudget is the rate limit budget of an API, as last reported by its responses.
+type rateLimitBudget struct {
+	mu sync.Mutex
+	// exhausted is set when a response reported that no requests remain until reset.
+	exhausted bool
+	reset     time.Time
+}
+
+// update records the budget reported by the response headers. Responses without the headers leave it unchanged.
+func (b *rateLimitBudget) update(header http.Header, now time.Time) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if remaining > 0 {
+		b.exhausted = false
+		return
+	}
+	if reset, ok := parseRateLimitReset(header.Get("X-RateLimit-Reset"), now); ok {
+		b.exhausted, b.reset = true, reset
+	}
+}
+
+// wait waits until the budget is reset if it is exhausted, unless the wait would exceed the deadline
+// of the request context.
+func (b *rateLimitBudget) wait(req *http.Request) error {
+	b.mu.Lock()
+	exhausted, reset := b.exhausted, b.reset
+	b.mu.Unlock()
+
+	delay := time.Until(reset)
+	if !exhausted || delay <= 0 {
+		return nil
+	}
+	if deadline, ok := req.Context().Deadline(); ok && time.Now().Add(delay).After(deadline) {
+		return fmt.Errorf("%w: no requests remain until %s, after the timeout",
+			ErrRateLimited, reset.UTC().Format(time.RFC3339))
+	}
+	return sleepContext(req.Context(), delay)
+}
+
+// maskToken returns a placeholder for token suitable for logging.
+func maskToken(token string) string {
+	if token == "" {
+		return ""
+	}
+	return maskedToken
+}
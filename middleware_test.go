@@ -0,0 +1,332 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestAuthMiddleware tests that the AuthMiddleware sets the Authorization header.
+func TestAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	token := "secret-token"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), "Bearer "+token; got != want {
+			t.Errorf("Authorization = %q, want %q", got, want)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{Transport: AuthMiddleware(token, server.Listener.Addr().String())(http.DefaultTransport)}
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("client.Do() unexpected error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	// The middleware must not modify the caller's request.
+	if req.Header.Get("Authorization") != "" {
+		t.Error("AuthMiddleware modified the original request headers")
+	}
+}
+
+// TestAuthMiddlewareOtherHost tests that the AuthMiddleware does not send the token to other hosts.
+func TestAuthMiddlewareOtherHost(t *testing.T) {
+	t.Parallel()
+
+	token := "secret-token"
+
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("Authorization = %q, want none for another host", got)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(other.Close)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), "Bearer "+token; got != want {
+			t.Errorf("Authorization = %q, want %q", got, want)
+		}
+		http.Redirect(w, r, other.URL+"/download", http.StatusFound)
+	}))
+	t.Cleanup(api.Close)
+
+	client := &http.Client{Transport: AuthMiddleware(token, api.Listener.Addr().String())(http.DefaultTransport)}
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{name: "request to another host", url: other.URL},
+		{name: "redirect to another host", url: api.URL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, tt.url, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			// A header set by the caller must not reach another host either.
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("client.Do() unexpected error = %v", err)
+			}
+			_ = resp.Body.Close()
+		})
+	}
+}
+
+// TestDryRunMiddleware tests that the DryRunMiddleware prints requests instead of sending them.
+func TestDryRunMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	var out bytes.Buffer
+	client := newHTTPClient(10*time.Second, "secret-token", server.Listener.Addr().String(), &out)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL+"/lookup?purl=pkg%3Anpm%2Flodash", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("User-Agent", "purlinfo/test")
+
+	resp, err := client.Do(req)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if !errors.Is(err, ErrDryRun) {
+		t.Errorf("client.Do() error = %v, want %v", err, ErrDryRun)
+	}
+	if got := requests.Load(); got != 0 {
+		t.Errorf("server received %d requests, want 0", got)
+	}
+
+	want := "GET " + server.URL + "/lookup?purl=pkg%3Anpm%2Flodash\n" +
+		"  Authorization: ****\n" +
+		"  User-Agent: purlinfo/test\n"
+	if out.String() != want {
+		t.Errorf("DryRunMiddleware output = %q, want %q", out.String(), want)
+	}
+	if strings.Contains(out.String(), "secret-token") {
+		t.Error("DryRunMiddleware output contains the token")
+	}
+}
+
+// TestRateLimitMiddleware tests that the RateLimitMiddleware waits for the budget reset once it is exhausted.
+func TestRateLimitMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		remaining string
+		reset     string
+		timeout   time.Duration
+		wantErr   error
+		wantWait  bool
+		wantSent  int32
+	}{
+		{name: "budget left", remaining: "10", reset: "60", timeout: time.Second, wantSent: 2},
+		{name: "no rate limit headers", timeout: time.Second, wantSent: 2},
+		{name: "budget exhausted", remaining: "0", reset: "1", timeout: 5 * time.Second, wantWait: true, wantSent: 2},
+		{
+			name:      "reset after the timeout",
+			remaining: "0",
+			reset:     "60",
+			timeout:   time.Second,
+			wantErr:   ErrRateLimited,
+			wantSent:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var sent atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				sent.Add(1)
+				if tt.remaining != "" {
+					w.Header().Set("X-RateLimit-Remaining", tt.remaining)
+					w.Header().Set("X-RateLimit-Reset", tt.reset)
+				}
+			}))
+			t.Cleanup(server.Close)
+
+			client := &http.Client{Transport: RateLimitMiddleware()(http.DefaultTransport)}
+
+			var elapsed time.Duration
+			var err error
+			for range 2 {
+				ctx, cancel := context.WithTimeout(t.Context(), tt.timeout)
+				req, reqErr := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+				if reqErr != nil {
+					cancel()
+					t.Fatalf("failed to create request: %v", reqErr)
+				}
+
+				start := time.Now()
+				var resp *http.Response
+				resp, err = client.Do(req)
+				elapsed = time.Since(start)
+				if err == nil {
+					_ = resp.Body.Close()
+				}
+				cancel()
+			}
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("second request error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Errorf("second request unexpected error = %v", err)
+			}
+			if waited := elapsed >= 500*time.Millisecond; waited != tt.wantWait {
+				t.Errorf("second request took %v, want wait = %v", elapsed, tt.wantWait)
+			}
+			if got := sent.Load(); got != tt.wantSent {
+				t.Errorf("requests sent = %d, want %d", got, tt.wantSent)
+			}
+		})
+	}
+}
+
+// TestRateLimitMiddleware_PerHost tests that the budget of one host does not throttle the requests to another.
+func TestRateLimitMiddleware_PerHost(t *testing.T) {
+	t.Parallel()
+
+	exhausted := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "60")
+	}))
+	t.Cleanup(exhausted.Close)
+	available := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "10")
+		w.Header().Set("X-RateLimit-Reset", "60")
+	}))
+	t.Cleanup(available.Close)
+
+	client := &http.Client{Transport: RateLimitMiddleware()(http.DefaultTransport)}
+	get := func(url string) error {
+		ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		resp, err := client.Do(req)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+
+	if err := get(exhausted.URL); err != nil {
+		t.Fatalf("first request unexpected error = %v", err)
+	}
+	if err := get(available.URL); err != nil {
+		t.Errorf("request to another host error = %v, want nil", err)
+	}
+	if err := get(exhausted.URL); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("second request to the exhausted host error = %v, want %v", err, ErrRateLimited)
+	}
+}
+
+// TestMaskToken tests the maskToken function.
+func TestMaskToken(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{
+			name:  "empty token",
+			token: "",
+			want:  "",
+		},
+		{
+			name:  "non-empty token",
+			token: "secret-token",
+			want:  "****",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := maskToken(tt.token); got != tt.want {
+				t.Errorf("maskToken(%q) = %q, want %q", tt.token, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNewHTTPClient tests the newHTTPClient function.
+func TestNewHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without token", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			if got := r.Header.Get("Authorization"); got != "" {
+				t.Errorf("Authorization = %q, want none when no token is set", got)
+			}
+		}))
+		t.Cleanup(server.Close)
+
+		client := newHTTPClient(10*time.Second, "", "", nil)
+		if client.Timeout != 10*time.Second {
+			t.Errorf("Timeout = %v, want %v", client.Timeout, 10*time.Second)
+		}
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("client.Do() unexpected error = %v", err)
+		}
+		_ = resp.Body.Close()
+	})
+
+	t.Run("with token", func(t *testing.T) {
+		t.Parallel()
+
+		client := newHTTPClient(10*time.Second, "secret-token", "api.example.com", nil)
+		if client.Transport == http.DefaultTransport {
+			t.Error("Transport should be wrapped when a token is set")
+		}
+	})
+}
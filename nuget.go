@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+const (
+	// nugetServiceIndexURL is the URL of the NuGet V3 service index.
+	//
+	// See https://learn.microsoft.com/en-us/nuget/api/service-index
+	nugetServiceIndexURL = "https://api.nuget.org/v3/index.json"
+	// nugetRegistrationsResourceType is the resource type of the package metadata resource.
+	nugetRegistrationsResourceType = "RegistrationsBaseUrl"
+	// nugetRegistrationsSemVer2ResourceType is the resource type of the package metadata resource
+	// that includes SemVer 2.0.0 packages.
+	nugetRegistrationsSemVer2ResourceType = "RegistrationsBaseUrl/3.6.0"
+)
+
+// NuGetService is the service for the NuGet V3 API.
+type NuGetService struct {
+	serviceIndexURL string
+	client          *http.Client
+}
+
+var _ Service = (*NuGetService)(nil)
+
+// NuGetServiceOptions are the options for the NuGetService.
+type NuGetServiceOptions struct {
+	// ServiceIndexURL is the URL of the NuGet V3 service index.
+	// If empty, defaults to the nuget.org service index.
+	ServiceIndexURL string
+	// Client is the HTTP client to use for the NuGet API.
+	// If nil, defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// NewNuGetService creates a new NuGetService.
+func NewNuGetService(opts NuGetServiceOptions) *NuGetService {
+	// Default to the nuget.org service index.
+	serviceIndexURL := nugetServiceIndexURL
+	if opts.ServiceIndexURL != "" {
+		serviceIndexURL = opts.ServiceIndexURL
+	}
+	// Default to the default HTTP client.
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &NuGetService{
+		serviceIndexURL: serviceIndexURL,
+		client:          client,
+	}
+}
+
+// nugetServiceIndexResponse is the response from the NuGet service index.
+type nugetServiceIndexResponse struct {
+	Resources []struct {
+		ID   string `json:"@id"`
+		Type string `json:"@type"`
+	} `json:"resources"`
+}
+
+// nugetRegistrationIndexResponse is the response from the NuGet registration index.
+type nugetRegistrationIndexResponse struct {
+	Items []nugetRegistrationPage `json:"items"`
+}
+
+// nugetRegistrationPage is a page of the NuGet registration index.
+//
+// Pages may not be inlined in the registration index, in which case Items is empty
+// and the page must be fetched from ID.
+type nugetRegistrationPage struct {
+	ID    string                  `json:"@id"`
+	Items []nugetRegistrationLeaf `json:"items"`
+}
+
+// nugetRegistrationLeaf is a single package version in the NuGet registration index.
+type nugetRegistrationLeaf struct {
+	CatalogEntry nugetCatalogEntry `json:"catalogEntry"`
+}
+
+// nugetCatalogEntry is the metadata of a single package version.
+type nugetCatalogEntry struct {
+	ID          string  `json:"id"`
+	Version     string  `json:"version"`
+	Description *string `json:"description"`
+	// Listed is false for unlisted versions, which are hidden from search (nil means listed).
+	Listed            *bool   `json:"listed"`
+	LicenseExpression *string `json:"licenseExpression"`
+	ProjectURL        *string `json:"projectUrl"`
+	Repository        *struct {
+		URL string `json:"url"`
+	} `json:"repository"`
+}
+
+// GetPackageInfo returns the information about a package.
+//
+// If the purl has no version, the latest listed stable version in the registration index is used,
+// or the latest listed prerelease version if there is no stable one.
+func (s *NuGetService) GetPackageInfo(ctx context.Context, purl packageurl.PackageURL) (PackageInfo, error) {
+	if purl.Type != packageurl.TypeNuget {
+		return PackageInfo{}, fmt.Errorf("%w: %s", ErrUnsupportedEcosystem, purl.Type)
+	}
+
+	registrationsBaseURL, err := s.registrationsBaseURL(ctx)
+	if err != nil {
+		return PackageInfo{}, err
+	}
+
+	// Package IDs are lowercased in registration URLs.
+	indexURL := fmt.Sprintf(
+		"%s/%s/index.json",
+		strings.TrimSuffix(registrationsBaseURL, "/"),
+		url.PathEscape(strings.ToLower(purl.Name)),
+	)
+
+	var index nugetRegistrationIndexResponse
+	if indexErr := getJSON(ctx, s.client, indexURL, nil, &index); indexErr != nil {
+		return PackageInfo{}, indexErr
+	}
+
+	entry, err := s.findCatalogEntry(ctx, index, purl.Version)
+	if err != nil {
+		return PackageInfo{}, fmt.Errorf("%w: %s", err, purl.String())
+	}
+
+	packageInfo := PackageInfo{
+		Name:        entry.ID,
+		Version:     entry.Version,
+		Licenses:    []string{},
+		Homepage:    stringValue(entry.ProjectURL),
+		Description: stringValue(entry.Description),
+		Ecosystem:   purl.Type,
+	}
+	if license := stringValue(entry.LicenseExpression); license != "" {
+		packageInfo.Licenses = []string{license}
+	}
+	if entry.Repository != nil {
+		packageInfo.RepositoryURL = entry.Repository.URL
+	}
+
+	return packageInfo, nil
+}
+
+// registrationsBaseURL resolves the package metadata resource URL from the service index.
+func (s *NuGetService) registrationsBaseURL(ctx context.Context) (string, error) {
+	var serviceIndex nugetServiceIndexResponse
+	if err := getJSON(ctx, s.client, s.serviceIndexURL, nil, &serviceIndex); err != nil {
+		return "", err
+	}
+
+	// Prefer the resource that includes SemVer 2.0.0 packages.
+	registrationsURL := ""
+	for _, resource := range serviceIndex.Resources {
+		if resource.Type == nugetRegistrationsSemVer2ResourceType {
+			return resource.ID, nil
+		}
+		if registrationsURL == "" && strings.HasPrefix(resource.Type, nugetRegistrationsResourceType) {
+			registrationsURL = resource.ID
+		}
+	}
+
+	if registrationsURL == "" {
+		return "", fmt.Errorf("%w: no %s resource in service index", ErrInvalidResponse, nugetRegistrationsResourceType)
+	}
+
+	return registrationsURL, nil
+}
+
+// findCatalogEntry returns the catalog entry for version or, if version is empty, the latest listed
+// stable version, falling back to the latest listed prerelease version.
+func (s *NuGetService) findCatalogEntry(
+	ctx context.Context,
+	index nugetRegistrationIndexResponse,
+	version string,
+) (nugetCatalogEntry, error) {
+	var prerelease *nugetCatalogEntry
+
+	// Pages are ordered from the oldest to the latest version, so walk them backwards.
+	for i := len(index.Items) - 1; i >= 0; i-- {
+		page := index.Items[i]
+		if len(page.Items) == 0 {
+			if err := getJSON(ctx, s.client, page.ID, nil, &page); err != nil {
+				return nugetCatalogEntry{}, err
+			}
+		}
+
+		for j := len(page.Items) - 1; j >= 0; j-- {
+			entry := page.Items[j].CatalogEntry
+			switch {
+			case version != "":
+				if strings.EqualFold(entry.Version, version) {
+					return entry, nil
+				}
+			case entry.Listed != nil && !*entry.Listed:
+			case !isNuGetPrerelease(entry.Version):
+				return entry, nil
+			case prerelease == nil:
+				prerelease = &entry
+			}
+		}
+	}
+
+	if prerelease != nil {
+		return *prerelease, nil
+	}
+	return nugetCatalogEntry{}, ErrPackageNotFound
+}
+
+// isNuGetPrerelease reports whether a NuGet version is a prerelease version, which has a SemVer
+// prerelease label (e.g., 1.0.0-beta1), ignoring the build metadata.
+func isNuGetPrerelease(version string) bool {
+	version, _, _ = strings.Cut(version, "+")
+	return strings.Contains(version, "-")
+}
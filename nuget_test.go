@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// newNuGetTestServer creates a mock NuGet V3 API with an inlined page (1.x) and a paged page (2.x).
+func newNuGetTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/v3/index.json", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"resources": [
+			{"@id": "%[1]s/search", "@type": "SearchQueryService"},
+			{"@id": "%[1]s/registration/", "@type": "RegistrationsBaseUrl"},
+			{"@id": "%[1]s/registration-semver2/", "@type": "RegistrationsBaseUrl/3.6.0"}
+		]}`, server.URL)
+	})
+	mux.HandleFunc("/registration-semver2/newtonsoft.json/index.json", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"items": [
+			{"@id": "%[1]s/page/1.json", "items": [
+				{"catalogEntry": {"id": "Newtonsoft.Json", "version": "1.0.0", "description": "Old"}}
+			]},
+			{"@id": "%[1]s/registration-semver2/newtonsoft.json/page/2.json"}
+		]}`, server.URL)
+	})
+	mux.HandleFunc("/registration-semver2/newtonsoft.json/page/2.json", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"items": [
+			{"catalogEntry": {"id": "Newtonsoft.Json", "version": "13.0.2"}},
+			{"catalogEntry": {
+				"id": "Newtonsoft.Json",
+				"version": "13.0.3",
+				"description": "Json.NET is a popular high-performance JSON framework for .NET",
+				"licenseExpression": "MIT",
+				"projectUrl": "https://www.newtonsoft.com/json",
+				"repository": {"url": "https://github.com/JamesNK/Newtonsoft.Json"}
+			}}
+		]}`))
+	})
+
+	return server
+}
+
+// TestNewNuGetService tests the NewNuGetService function.
+func TestNewNuGetService(t *testing.T) {
+	t.Parallel()
+
+	service := NewNuGetService(NuGetServiceOptions{})
+
+	if service.serviceIndexURL != nugetServiceIndexURL {
+		t.Errorf("serviceIndexURL = %q, want %q", service.serviceIndexURL, nugetServiceIndexURL)
+	}
+	if service.client != http.DefaultClient {
+		t.Error("client should be http.DefaultClient when not provided")
+	}
+}
+
+// TestNuGetService_GetPackageInfo tests the GetPackageInfo method.
+func TestNuGetService_GetPackageInfo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		purl    string
+		want    PackageInfo
+		wantErr error
+	}{
+		{
+			name: "latest version",
+			purl: "pkg:nuget/Newtonsoft.Json",
+			want: PackageInfo{
+				Name:          "Newtonsoft.Json",
+				Version:       "13.0.3",
+				Licenses:      []string{"MIT"},
+				Homepage:      "https://www.newtonsoft.com/json",
+				RepositoryURL: "https://github.com/JamesNK/Newtonsoft.Json",
+				Description:   "Json.NET is a popular high-performance JSON framework for .NET",
+				Ecosystem:     "nuget",
+			},
+		},
+		{
+			name: "specific version in inlined page",
+			purl: "pkg:nuget/Newtonsoft.Json@1.0.0",
+			want: PackageInfo{
+				Name:        "Newtonsoft.Json",
+				Version:     "1.0.0",
+				Licenses:    []string{},
+				Description: "Old",
+				Ecosystem:   "nuget",
+			},
+		},
+		{
+			name:    "unknown version",
+			purl:    "pkg:nuget/Newtonsoft.Json@0.0.1",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name:    "unknown package",
+			purl:    "pkg:nuget/Missing",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name:    "unsupported ecosystem",
+			purl:    "pkg:npm/lodash@4.17.21",
+			wantErr: ErrUnsupportedEcosystem,
+		},
+	}
+
+	server := newNuGetTestServer(t)
+	service := NewNuGetService(NuGetServiceOptions{
+		ServiceIndexURL: server.URL + "/v3/index.json",
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.GetPackageInfo(context.Background(), purl)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetPackageInfo() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+
+			if got.Name != tt.want.Name {
+				t.Errorf("GetPackageInfo() Name = %q, want %q", got.Name, tt.want.Name)
+			}
+			if got.Version != tt.want.Version {
+				t.Errorf("GetPackageInfo() Version = %q, want %q", got.Version, tt.want.Version)
+			}
+			if got.Ecosystem != tt.want.Ecosystem {
+				t.Errorf("GetPackageInfo() Ecosystem = %q, want %q", got.Ecosystem, tt.want.Ecosystem)
+			}
+			if !equalStringSlices(got.Licenses, tt.want.Licenses) {
+				t.Errorf("GetPackageInfo() Licenses = %v, want %v", got.Licenses, tt.want.Licenses)
+			}
+			if got.Homepage != tt.want.Homepage {
+				t.Errorf("GetPackageInfo() Homepage = %q, want %q", got.Homepage, tt.want.Homepage)
+			}
+			if got.RepositoryURL != tt.want.RepositoryURL {
+				t.Errorf("GetPackageInfo() RepositoryURL = %q, want %q", got.RepositoryURL, tt.want.RepositoryURL)
+			}
+			if got.Description != tt.want.Description {
+				t.Errorf("GetPackageInfo() Description = %q, want %q", got.Description, tt.want.Description)
+			}
+		})
+	}
+}
+
+// TestNuGetService_GetPackageInfo_NoRegistrationsResource tests a service index without package metadata.
+func TestNuGetService_GetPackageInfo_NoRegistrationsResource(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"resources": [{"@id": "https://example.com/search", "@type": "SearchQueryService"}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	service := NewNuGetService(NuGetServiceOptions{
+		ServiceIndexURL: server.URL,
+	})
+
+	purl, err := packageurl.FromString("pkg:nuget/Newtonsoft.Json")
+	if err != nil {
+		t.Fatalf("failed to parse purl: %v", err)
+	}
+
+	_, err = service.GetPackageInfo(context.Background(), purl)
+	if !errors.Is(err, ErrInvalidResponse) {
+		t.Errorf("GetPackageInfo() error = %v, want %v", err, ErrInvalidResponse)
+	}
+}
+
+// TestNuGetService_GetPackageInfo_LatestListedStable tests the version used for a purl without a version.
+func TestNuGetService_GetPackageInfo_LatestListedStable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		catalog     string
+		wantVersion string
+		wantErr     error
+	}{
+		{
+			name: "prerelease and unlisted versions are skipped",
+			catalog: `{"catalogEntry": {"id": "Demo", "version": "1.0.0"}},
+				{"catalogEntry": {"id": "Demo", "version": "1.1.0+build.5", "listed": true}},
+				{"catalogEntry": {"id": "Demo", "version": "2.0.0", "listed": false}},
+				{"catalogEntry": {"id": "Demo", "version": "2.1.0-rc.1"}}`,
+			wantVersion: "1.1.0+build.5",
+		},
+		{
+			name: "newest listed prerelease without stable versions",
+			catalog: `{"catalogEntry": {"id": "Demo", "version": "0.1.0-alpha"}},
+				{"catalogEntry": {"id": "Demo", "version": "0.2.0-beta"}},
+				{"catalogEntry": {"id": "Demo", "version": "0.3.0-rc", "listed": false}}`,
+			wantVersion: "0.2.0-beta",
+		},
+		{
+			name:    "only unlisted versions",
+			catalog: `{"catalogEntry": {"id": "Demo", "version": "1.0.0", "listed": false}}`,
+			wantErr: ErrPackageNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := http.NewServeMux()
+			server := httptest.NewServer(mux)
+			t.Cleanup(server.Close)
+
+			mux.HandleFunc("/v3/index.json", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprintf(w, `{"resources": [{"@id": "%s/registration/", "@type": "RegistrationsBaseUrl"}]}`, server.URL)
+			})
+			mux.HandleFunc("/registration/demo/index.json", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprintf(w, `{"items": [{"@id": "page", "items": [%s]}]}`, tt.catalog)
+			})
+
+			service := NewNuGetService(NuGetServiceOptions{
+				ServiceIndexURL: server.URL + "/v3/index.json",
+			})
+
+			purl, err := packageurl.FromString("pkg:nuget/Demo")
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.GetPackageInfo(context.Background(), purl)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetPackageInfo() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+			if got.Version != tt.wantVersion {
+				t.Errorf("GetPackageInfo() Version = %q, want %q", got.Version, tt.wantVersion)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// packagistBaseURL is the base URL for the Packagist API.
+//
+// See https://packagist.org/apidoc
+const packagistBaseURL = "https://packagist.org"
+
+// PackagistService is the service for the Packagist API of PHP Composer packages.
+type PackagistService struct {
+	baseURL string
+	client  *http.Client
+}
+
+var _ Service = (*PackagistService)(nil)
+
+// PackagistServiceOptions are the options for the PackagistService.
+type PackagistServiceOptions struct {
+	// BaseURL is the base URL for the Packagist API.
+	// If empty, defaults to packagist.org.
+	BaseURL string
+	// Client is the HTTP client to use for the Packagist API.
+	// If nil, defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// NewPackagistService creates a new PackagistService.
+func NewPackagistService(opts PackagistServiceOptions) *PackagistService {
+	// Default to the Packagist API base URL.
+	baseURL := packagistBaseURL
+	if opts.BaseURL != "" {
+		baseURL = opts.BaseURL
+	}
+	// Default to the default HTTP client.
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &PackagistService{
+		baseURL: baseURL,
+		client:  client,
+	}
+}
+
+// packagistPackageResponse is the response from the Packagist package endpoint.
+type packagistPackageResponse struct {
+	Package struct {
+		Name        string                      `json:"name"`
+		Description string                      `json:"description"`
+		Repository  string                      `json:"repository"`
+		Versions    map[string]packagistVersion `json:"versions"`
+	} `json:"package"`
+}
+
+// packagistVersion is a version of a package in the response from the Packagist package endpoint,
+// keyed by its tag or branch name.
+type packagistVersion struct {
+	Version           string   `json:"version"`
+	VersionNormalized string   `json:"version_normalized"`
+	License           []string `json:"license"`
+	Description       string   `json:"description"`
+	Homepage          string   `json:"homepage"`
+	Source            *struct {
+		URL string `json:"url"`
+	} `json:"source"`
+}
+
+// GetPackageInfo returns the information about a package.
+//
+// The vendor is the purl namespace. Without a version, the latest stable version is used.
+func (s *PackagistService) GetPackageInfo(ctx context.Context, purl packageurl.PackageURL) (PackageInfo, error) {
+	if purl.Type != packageurl.TypeComposer {
+		return PackageInfo{}, fmt.Errorf("%w: %s", ErrUnsupportedEcosystem, purl.Type)
+	}
+	if purl.Namespace == "" {
+		return PackageInfo{}, fmt.Errorf("%w: missing the vendor of %s", ErrInvalidPURL, purl.Name)
+	}
+
+	packageURL := fmt.Sprintf("%s/packages/%s/%s.json",
+		s.baseURL, url.PathEscape(purl.Namespace), url.PathEscape(purl.Name))
+
+	var result packagistPackageResponse
+	if err := getJSON(ctx, s.client, packageURL, nil, &result); err != nil {
+		return PackageInfo{}, err
+	}
+	pkg := result.Package
+
+	version, ok := packagistFindVersion(pkg.Versions, purl.Version)
+	if !ok && purl.Version == "" {
+		return PackageInfo{}, fmt.Errorf("%w: %s/%s has no tagged versions", ErrPackageNotFound,
+			purl.Namespace, purl.Name)
+	}
+	if !ok {
+		return PackageInfo{}, fmt.Errorf("%w: %s/%s version %s", ErrPackageNotFound,
+			purl.Namespace, purl.Name, purl.Version)
+	}
+
+	packageInfo := PackageInfo{
+		Name:          pkg.Name,
+		Version:       version.Version,
+		Licenses:      version.License,
+		Homepage:      version.Homepage,
+		RepositoryURL: pkg.Repository,
+		Description:   pkg.Description,
+		Ecosystem:     purl.Type,
+	}
+	if version.Description != "" {
+		packageInfo.Description = version.Description
+	}
+	if packageInfo.RepositoryURL == "" && version.Source != nil {
+		packageInfo.RepositoryURL = version.Source.URL
+	}
+	if packageInfo.Licenses == nil {
+		packageInfo.Licenses = []string{}
+	}
+
+	return packageInfo, nil
+}
+
+// packagistFindVersion returns the version of a package matching the purl version, ignoring a leading v
+// since Packagist keys versions by their tag.
+//
+// Without a purl version, it returns the newest stable version, or the newest tagged version if there is
+// no stable one. It returns false if the package has no tagged versions, only development branches.
+func packagistFindVersion(versions map[string]packagistVersion, version string) (packagistVersion, bool) {
+	if version != "" {
+		if v, ok := versions[version]; ok {
+			return v, true
+		}
+		for name, v := range versions {
+			if strings.TrimPrefix(name, "v") == strings.TrimPrefix(version, "v") {
+				return v, true
+			}
+		}
+		return packagistVersion{}, false
+	}
+
+	var latest, latestStable packagistVersion
+	for _, v := range versions {
+		// Branches are dev-<branch> or <n>.x-dev, normalized to a -dev suffix
+		if strings.HasPrefix(v.VersionNormalized, "dev-") || strings.HasSuffix(v.VersionNormalized, "-dev") {
+			continue
+		}
+		if latest.Version == "" || compareVersions(v.VersionNormalized, latest.VersionNormalized) > 0 {
+			latest = v
+		}
+		stable := strings.Trim(v.VersionNormalized, "0123456789.") == ""
+		if stable && (latestStable.Version == "" ||
+			compareVersions(v.VersionNormalized, latestStable.VersionNormalized) > 0) {
+			latestStable = v
+		}
+	}
+	if latestStable.Version != "" {
+		return latestStable, true
+	}
+	return latest, latest.Version != ""
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// TestNewPackagistService tests the NewPackagistService function.
+func TestNewPackagistService(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default options", func(t *testing.T) {
+		t.Parallel()
+
+		service := NewPackagistService(PackagistServiceOptions{})
+
+		if service.baseURL != packagistBaseURL {
+			t.Errorf("baseURL = %q, want %q", service.baseURL, packagistBaseURL)
+		}
+		if service.client != http.DefaultClient {
+			t.Error("client should be http.DefaultClient when not provided")
+		}
+	})
+
+	t.Run("custom base URL", func(t *testing.T) {
+		t.Parallel()
+
+		customURL := "https://packagist.example.com"
+		service := NewPackagistService(PackagistServiceOptions{
+			BaseURL: customURL,
+		})
+
+		if service.baseURL != customURL {
+			t.Errorf("baseURL = %q, want %q", service.baseURL, customURL)
+		}
+	})
+}
+
+// TestPackagistService_GetPackageInfo tests the GetPackageInfo method.
+func TestPackagistService_GetPackageInfo(t *testing.T) {
+	t.Parallel()
+
+	const monologPackage = `{
+		"package": {
+			"name": "monolog/monolog",
+			"description": "Sends your logs to files, sockets, inboxes, databases and various web services",
+			"repository": "https://github.com/Seldaek/monolog",
+			"versions": {
+				"dev-main": {"version": "dev-main", "version_normalized": "dev-main", "license": ["MIT"]},
+				"3.8.0-RC1": {"version": "3.8.0-RC1", "version_normalized": "3.8.0.0-RC1", "license": ["MIT"]},
+				"3.7.0": {
+					"version": "3.7.0",
+					"version_normalized": "3.7.0.0",
+					"license": ["MIT"],
+					"description": "Sends your logs to files, sockets, inboxes, databases and various web services",
+					"homepage": "https://github.com/Seldaek/monolog"
+				},
+				"1.0.0": {
+					"version": "1.0.0",
+					"version_normalized": "1.0.0.0",
+					"description": "Logging for PHP 5.3",
+					"source": {"url": "https://github.com/Seldaek/monolog.git"}
+				}
+			}
+		}
+	}`
+
+	tests := []struct {
+		name           string
+		mockResponse   string
+		mockStatusCode int
+		purl           string
+		wantPath       string
+		want           PackageInfo
+		wantErr        error
+	}{
+		{
+			name:           "package without version",
+			mockResponse:   monologPackage,
+			mockStatusCode: http.StatusOK,
+			purl:           "pkg:composer/monolog/monolog",
+			wantPath:       "/packages/monolog/monolog.json",
+			want: PackageInfo{
+				Name:          "monolog/monolog",
+				Version:       "3.7.0",
+				Licenses:      []string{"MIT"},
+				Homepage:      "https://github.com/Seldaek/monolog",
+				RepositoryURL: "https://github.com/Seldaek/monolog",
+				Description:   "Sends your logs to files, sockets, inboxes, databases and various web services",
+				Ecosystem:     "composer",
+			},
+		},
+		{
+			name:           "older version with v prefix",
+			mockResponse:   monologPackage,
+			mockStatusCode: http.StatusOK,
+			purl:           "pkg:composer/monolog/monolog@v1.0.0",
+			wantPath:       "/packages/monolog/monolog.json",
+			want: PackageInfo{
+				Name:          "monolog/monolog",
+				Version:       "1.0.0",
+				Licenses:      []string{},
+				RepositoryURL: "https://github.com/Seldaek/monolog",
+				Description:   "Logging for PHP 5.3",
+				Ecosystem:     "composer",
+			},
+		},
+		{
+			name: "repository from the version source",
+			mockResponse: `{"package": {"name": "acme/tool", "versions": {
+				"2.0.0": {
+					"version": "2.0.0",
+					"version_normalized": "2.0.0.0",
+					"source": {"url": "https://git.example.com/tool.git"}
+				}
+			}}}`,
+			mockStatusCode: http.StatusOK,
+			purl:           "pkg:composer/acme/tool@2.0.0",
+			wantPath:       "/packages/acme/tool.json",
+			want: PackageInfo{
+				Name:          "acme/tool",
+				Version:       "2.0.0",
+				Licenses:      []string{},
+				RepositoryURL: "https://git.example.com/tool.git",
+				Ecosystem:     "composer",
+			},
+		},
+		{
+			name:           "missing version",
+			mockResponse:   monologPackage,
+			mockStatusCode: http.StatusOK,
+			purl:           "pkg:composer/monolog/monolog@9.9.9",
+			wantPath:       "/packages/monolog/monolog.json",
+			wantErr:        ErrPackageNotFound,
+		},
+		{
+			name: "package with development branches only",
+			mockResponse: `{"package": {"name": "acme/wip", "versions": {
+				"dev-main": {"version": "dev-main", "version_normalized": "dev-main"}
+			}}}`,
+			mockStatusCode: http.StatusOK,
+			purl:           "pkg:composer/acme/wip",
+			wantPath:       "/packages/acme/wip.json",
+			wantErr:        ErrPackageNotFound,
+		},
+		{
+			name:           "HTTP 404 error",
+			mockResponse:   `{"status": "error", "message": "Package not found"}`,
+			mockStatusCode: http.StatusNotFound,
+			purl:           "pkg:composer/acme/missing",
+			wantPath:       "/packages/acme/missing.json",
+			wantErr:        ErrPackageNotFound,
+		},
+		{
+			name:           "malformed JSON",
+			mockResponse:   `{invalid json}`,
+			mockStatusCode: http.StatusOK,
+			purl:           "pkg:composer/monolog/monolog",
+			wantPath:       "/packages/monolog/monolog.json",
+			wantErr:        ErrInvalidResponse,
+		},
+		{
+			name:    "missing vendor",
+			purl:    "pkg:composer/monolog",
+			wantErr: ErrInvalidPURL,
+		},
+		{
+			name:    "unsupported ecosystem",
+			purl:    "pkg:pub/http",
+			wantErr: ErrUnsupportedEcosystem,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.wantPath {
+					t.Errorf("request path = %q, want %q", r.URL.Path, tt.wantPath)
+				}
+
+				w.WriteHeader(tt.mockStatusCode)
+				_, _ = w.Write([]byte(tt.mockResponse))
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewPackagistService(PackagistServiceOptions{
+				BaseURL: server.URL,
+			})
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.GetPackageInfo(context.Background(), purl)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetPackageInfo() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+
+			if got.Name != tt.want.Name {
+				t.Errorf("GetPackageInfo() Name = %q, want %q", got.Name, tt.want.Name)
+			}
+			if got.Version != tt.want.Version {
+				t.Errorf("GetPackageInfo() Version = %q, want %q", got.Version, tt.want.Version)
+			}
+			if got.Ecosystem != tt.want.Ecosystem {
+				t.Errorf("GetPackageInfo() Ecosystem = %q, want %q", got.Ecosystem, tt.want.Ecosystem)
+			}
+			if !equalStringSlices(got.Licenses, tt.want.Licenses) {
+				t.Errorf("GetPackageInfo() Licenses = %v, want %v", got.Licenses, tt.want.Licenses)
+			}
+			if got.Homepage != tt.want.Homepage {
+				t.Errorf("GetPackageInfo() Homepage = %q, want %q", got.Homepage, tt.want.Homepage)
+			}
+			if got.RepositoryURL != tt.want.RepositoryURL {
+				t.Errorf("GetPackageInfo() RepositoryURL = %q, want %q", got.RepositoryURL, tt.want.RepositoryURL)
+			}
+			if got.Description != tt.want.Description {
+				t.Errorf("GetPackageInfo() Description = %q, want %q", got.Description, tt.want.Description)
+			}
+		})
+	}
+}
+
+// TestPackagistFindVersion tests the packagistFindVersion function.
+func TestPackagistFindVersion(t *testing.T) {
+	t.Parallel()
+
+	versionsOf := func(names ...string) map[string]packagistVersion {
+		versions := map[string]packagistVersion{}
+		for _, name := range names {
+			normalized := strings.TrimPrefix(name, "v")
+			if strings.HasPrefix(name, "dev-") {
+				normalized = name
+			}
+			versions[name] = packagistVersion{Version: name, VersionNormalized: normalized}
+		}
+		return versions
+	}
+
+	tests := []struct {
+		name     string
+		versions map[string]packagistVersion
+		version  string
+		want     string
+		wantOK   bool
+	}{
+		{
+			name:     "latest stable",
+			versions: versionsOf("1.9.0", "1.10.0", "2.0.0-beta1", "dev-main"),
+			want:     "1.10.0",
+			wantOK:   true,
+		},
+		{
+			name:     "latest pre-release",
+			versions: versionsOf("0.1.0-alpha", "0.2.0-beta", "dev-main"),
+			want:     "0.2.0-beta",
+			wantOK:   true,
+		},
+		{name: "branches only", versions: versionsOf("dev-main", "1.x-dev"), wantOK: false},
+		{name: "exact version", versions: versionsOf("v1.0.0", "1.0.0"), version: "v1.0.0", want: "v1.0.0", wantOK: true},
+		{name: "version without v", versions: versionsOf("v5.4.0"), version: "5.4.0", want: "v5.4.0", wantOK: true},
+		{name: "version with v", versions: versionsOf("5.4.0"), version: "v5.4.0", want: "5.4.0", wantOK: true},
+		{name: "branch", versions: versionsOf("dev-main"), version: "dev-main", want: "dev-main", wantOK: true},
+		{name: "missing version", versions: versionsOf("1.0.0"), version: "2.0.0", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := packagistFindVersion(tt.versions, tt.version)
+			if ok != tt.wantOK {
+				t.Fatalf("packagistFindVersion() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if got.Version != tt.want {
+				t.Errorf("packagistFindVersion() = %q, want %q", got.Version, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+const (
+	// pubBaseURL is the base URL for the pub.dev API.
+	//
+	// See https://pub.dev/help/api
+	pubBaseURL = "https://pub.dev"
+	// pubPackagesAPIPath is the API path for package information.
+	pubPackagesAPIPath = "/api/packages"
+	// pubLicenseTagPrefix is the prefix of the score tags naming the license of a package (e.g., license:mit).
+	pubLicenseTagPrefix = "license:"
+)
+
+// PubService is the service for the pub.dev API of Dart and Flutter packages.
+//
+// Pubspecs have no license field: the licenses come from the tags of the package score, which pub.dev
+// computes for the latest version only, as lowercase SPDX identifiers. Other versions have no licenses.
+type PubService struct {
+	baseURL string
+	client  *http.Client
+}
+
+var _ Service = (*PubService)(nil)
+
+// PubServiceOptions are the options for the PubService.
+type PubServiceOptions struct {
+	// BaseURL is the base URL for the pub.dev API.
+	// If empty, defaults to pub.dev.
+	BaseURL string
+	// Client is the HTTP client to use for the pub.dev API.
+	// If nil, defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// NewPubService creates a new PubService.
+func NewPubService(opts PubServiceOptions) *PubService {
+	// Default to the pub.dev API base URL.
+	baseURL := pubBaseURL
+	if opts.BaseURL != "" {
+		baseURL = opts.BaseURL
+	}
+	// Default to the default HTTP client.
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &PubService{
+		baseURL: baseURL,
+		client:  client,
+	}
+}
+
+// pubPackageResponse is the response from the pub.dev package endpoint.
+type pubPackageResponse struct {
+	Name     string       `json:"name"`
+	Latest   pubVersion   `json:"latest"`
+	Versions []pubVersion `json:"versions"`
+}
+
+// pubVersion is a version of a package in the response from the pub.dev package endpoint.
+type pubVersion struct {
+	Version string `json:"version"`
+	Pubspec struct {
+		Description   *string `json:"description"`
+		Homepage      *string `json:"homepage"`
+		Repository    *string `json:"repository"`
+		IssueTracker  *string `json:"issue_tracker"`
+		Documentation *string `json:"documentation"`
+	} `json:"pubspec"`
+}
+
+// pubScoreResponse is the response from the pub.dev package score endpoint.
+type pubScoreResponse struct {
+	Tags []string `json:"tags"`
+}
+
+// GetPackageInfo returns the information about a package.
+//
+// If the purl has a version, the pubspec of that version is used. The licenses are only known for
+// the latest version (see PubService).
+func (s *PubService) GetPackageInfo(ctx context.Context, purl packageurl.PackageURL) (PackageInfo, error) {
+	if purl.Type != packageurl.TypePub {
+		return PackageInfo{}, fmt.Errorf("%w: %s", ErrUnsupportedEcosystem, purl.Type)
+	}
+
+	packageURL := fmt.Sprintf("%s%s/%s", s.baseURL, pubPackagesAPIPath, url.PathEscape(purl.Name))
+
+	var pkg pubPackageResponse
+	if err := getJSON(ctx, s.client, packageURL, nil, &pkg); err != nil {
+		return PackageInfo{}, err
+	}
+
+	version := pkg.Latest
+	if purl.Version != "" && purl.Version != pkg.Latest.Version {
+		i := slices.IndexFunc(pkg.Versions, func(v pubVersion) bool { return v.Version == purl.Version })
+		if i < 0 {
+			return PackageInfo{}, fmt.Errorf("%w: %s version %s", ErrPackageNotFound, purl.Name, purl.Version)
+		}
+		version = pkg.Versions[i]
+	}
+
+	licenses := []string{}
+	if version.Version == pkg.Latest.Version {
+		var err error
+		if licenses, err = s.getLicenses(ctx, packageURL); err != nil {
+			return PackageInfo{}, err
+		}
+	}
+
+	return PackageInfo{
+		Name:             pkg.Name,
+		Version:          version.Version,
+		Licenses:         licenses,
+		Homepage:         stringValue(version.Pubspec.Homepage),
+		RepositoryURL:    stringValue(version.Pubspec.Repository),
+		Description:      strings.TrimSpace(stringValue(version.Pubspec.Description)),
+		Ecosystem:        purl.Type,
+		DocumentationURL: stringValue(version.Pubspec.Documentation),
+		BugTrackerURL:    stringValue(version.Pubspec.IssueTracker),
+	}, nil
+}
+
+// getLicenses returns the licenses of the package at packageURL from the tags of its score.
+//
+// Packages that were not scored yet have no licenses.
+func (s *PubService) getLicenses(ctx context.Context, packageURL string) ([]string, error) {
+	var score pubScoreResponse
+	if err := getJSON(ctx, s.client, packageURL+"/score", nil, &score); err != nil {
+		if errors.Is(err, ErrPackageNotFound) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	licenses := []string{}
+	for _, tag := range score.Tags {
+		if license, ok := strings.CutPrefix(tag, pubLicenseTagPrefix); ok && !isPubLicenseClass(license) {
+			licenses = append(licenses, canonicalSPDXLicenseID(license))
+		}
+	}
+	return licenses, nil
+}
+
+// isPubLicenseClass reports whether the value of a license score tag classifies the license
+// rather than naming it.
+func isPubLicenseClass(value string) bool {
+	switch value {
+	case "fsf-libre", "osi-approved", "unknown":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// TestNewPubService tests the NewPubService function.
+func TestNewPubService(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default options", func(t *testing.T) {
+		t.Parallel()
+
+		service := NewPubService(PubServiceOptions{})
+
+		if service.baseURL != pubBaseURL {
+			t.Errorf("baseURL = %q, want %q", service.baseURL, pubBaseURL)
+		}
+		if service.client != http.DefaultClient {
+			t.Error("client should be http.DefaultClient when not provided")
+		}
+	})
+
+	t.Run("custom base URL", func(t *testing.T) {
+		t.Parallel()
+
+		customURL := "https://pub.example.com"
+		service := NewPubService(PubServiceOptions{
+			BaseURL: customURL,
+		})
+
+		if service.baseURL != customURL {
+			t.Errorf("baseURL = %q, want %q", service.baseURL, customURL)
+		}
+	})
+}
+
+// TestPubService_GetPackageInfo tests the GetPackageInfo method.
+func TestPubService_GetPackageInfo(t *testing.T) {
+	t.Parallel()
+
+	const httpPackage = `{
+		"name": "http",
+		"latest": {
+			"version": "1.2.2",
+			"pubspec": {
+				"description": "A composable, multi-platform, Future-based API for HTTP requests.\n",
+				"repository": "https://github.com/dart-lang/http/tree/master/pkgs/http",
+				"issue_tracker": "https://github.com/dart-lang/http/issues"
+			}
+		},
+		"versions": [
+			{"version": "0.13.6", "pubspec": {"description": "A HTTP API.", "homepage": "https://github.com/dart-lang/http"}},
+			{"version": "1.2.2", "pubspec": {}}
+		]
+	}`
+	const httpScore = `{"tags": ["sdk:dart", "license:bsd-3-clause", "license:osi-approved", "license:fsf-libre"]}`
+
+	// mockResponse is the status code and body of the response to a path.
+	type mockResponse struct {
+		statusCode int
+		body       string
+	}
+
+	tests := []struct {
+		name      string
+		responses map[string]mockResponse
+		purl      string
+		want      PackageInfo
+		wantErr   error
+	}{
+		{
+			name: "package without version",
+			responses: map[string]mockResponse{
+				"/api/packages/http":       {http.StatusOK, httpPackage},
+				"/api/packages/http/score": {http.StatusOK, httpScore},
+			},
+			purl: "pkg:pub/http",
+			want: PackageInfo{
+				Name:          "http",
+				Version:       "1.2.2",
+				Licenses:      []string{"BSD-3-Clause"},
+				RepositoryURL: "https://github.com/dart-lang/http/tree/master/pkgs/http",
+				Description:   "A composable, multi-platform, Future-based API for HTTP requests.",
+				Ecosystem:     "pub",
+				BugTrackerURL: "https://github.com/dart-lang/http/issues",
+			},
+		},
+		{
+			name: "older version has no licenses",
+			responses: map[string]mockResponse{
+				"/api/packages/http": {http.StatusOK, httpPackage},
+			},
+			purl: "pkg:pub/http@0.13.6",
+			want: PackageInfo{
+				Name:        "http",
+				Version:     "0.13.6",
+				Licenses:    []string{},
+				Homepage:    "https://github.com/dart-lang/http",
+				Description: "A HTTP API.",
+				Ecosystem:   "pub",
+			},
+		},
+		{
+			name: "package without score",
+			responses: map[string]mockResponse{
+				"/api/packages/http":       {http.StatusOK, httpPackage},
+				"/api/packages/http/score": {http.StatusNotFound, `{"error": {"code": "NotFound"}}`},
+			},
+			purl: "pkg:pub/http@1.2.2",
+			want: PackageInfo{
+				Name:          "http",
+				Version:       "1.2.2",
+				Licenses:      []string{},
+				RepositoryURL: "https://github.com/dart-lang/http/tree/master/pkgs/http",
+				Description:   "A composable, multi-platform, Future-based API for HTTP requests.",
+				Ecosystem:     "pub",
+				BugTrackerURL: "https://github.com/dart-lang/http/issues",
+			},
+		},
+		{
+			name: "missing version",
+			responses: map[string]mockResponse{
+				"/api/packages/http": {http.StatusOK, httpPackage},
+			},
+			purl:    "pkg:pub/http@9.9.9",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name: "missing package",
+			responses: map[string]mockResponse{
+				"/api/packages/missing": {http.StatusNotFound, `{"error": {"code": "NotFound"}}`},
+			},
+			purl:    "pkg:pub/missing",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name: "score server error",
+			responses: map[string]mockResponse{
+				"/api/packages/http":       {http.StatusOK, httpPackage},
+				"/api/packages/http/score": {http.StatusInternalServerError, `{}`},
+			},
+			purl:    "pkg:pub/http",
+			wantErr: ErrAPIError,
+		},
+		{
+			name: "malformed JSON",
+			responses: map[string]mockResponse{
+				"/api/packages/http": {http.StatusOK, `{invalid json}`},
+			},
+			purl:    "pkg:pub/http",
+			wantErr: ErrInvalidResponse,
+		},
+		{
+			name:    "unsupported ecosystem",
+			purl:    "pkg:hex/phoenix",
+			wantErr: ErrUnsupportedEcosystem,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				response, ok := tt.responses[r.URL.Path]
+				if !ok {
+					t.Errorf("unexpected request path %q", r.URL.Path)
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(response.statusCode)
+				_, _ = w.Write([]byte(response.body))
+			}))
+			t.Cleanup(server.Close)
+
+			service := NewPubService(PubServiceOptions{
+				BaseURL: server.URL,
+			})
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.GetPackageInfo(context.Background(), purl)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetPackageInfo() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+
+			if got.Name != tt.want.Name {
+				t.Errorf("GetPackageInfo() Name = %q, want %q", got.Name, tt.want.Name)
+			}
+			if got.Version != tt.want.Version {
+				t.Errorf("GetPackageInfo() Version = %q, want %q", got.Version, tt.want.Version)
+			}
+			if got.Ecosystem != tt.want.Ecosystem {
+				t.Errorf("GetPackageInfo() Ecosystem = %q, want %q", got.Ecosystem, tt.want.Ecosystem)
+			}
+			if !equalStringSlices(got.Licenses, tt.want.Licenses) {
+				t.Errorf("GetPackageInfo() Licenses = %v, want %v", got.Licenses, tt.want.Licenses)
+			}
+			if got.Homepage != tt.want.Homepage {
+				t.Errorf("GetPackageInfo() Homepage = %q, want %q", got.Homepage, tt.want.Homepage)
+			}
+			if got.RepositoryURL != tt.want.RepositoryURL {
+				t.Errorf("GetPackageInfo() RepositoryURL = %q, want %q", got.RepositoryURL, tt.want.RepositoryURL)
+			}
+			if got.Description != tt.want.Description {
+				t.Errorf("GetPackageInfo() Description = %q, want %q", got.Description, tt.want.Description)
+			}
+			if got.BugTrackerURL != tt.want.BugTrackerURL {
+				t.Errorf("GetPackageInfo() BugTrackerURL = %q, want %q", got.BugTrackerURL, tt.want.BugTrackerURL)
+			}
+		})
+	}
+}
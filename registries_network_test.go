@@ -0,0 +1,127 @@
+//go:build network
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// TestRegistryServices_Network tests the backends that query package registries directly
+// against the public registries.
+//
+// The GitHub Packages backend is not covered because it requires a token.
+func TestRegistryServices_Network(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping network test in short mode")
+	}
+
+	tests := []struct {
+		name     string
+		service  Service
+		purl     string
+		wantName string
+	}{
+		{
+			name:     "rubygems",
+			service:  NewRubyGemsService(RubyGemsServiceOptions{}),
+			purl:     "pkg:gem/rails@7.0.0",
+			wantName: "rails",
+		},
+		{
+			name:     "nuget",
+			service:  NewNuGetService(NuGetServiceOptions{}),
+			purl:     "pkg:nuget/Newtonsoft.Json@13.0.3",
+			wantName: "Newtonsoft.Json",
+		},
+		{
+			name:     "maven central",
+			service:  NewMavenCentralService(MavenCentralServiceOptions{}),
+			purl:     "pkg:maven/com.google.guava/guava@32.1.2-jre",
+			wantName: "com.google.guava:guava",
+		},
+		{
+			name:     "go module proxy",
+			service:  NewGoModuleProxyService(GoModuleProxyServiceOptions{}),
+			purl:     "pkg:golang/golang.org/x/text@v0.13.0",
+			wantName: "golang.org/x/text",
+		},
+		{
+			name:     "github actions",
+			service:  NewGitHubActionsService(GitHubActionsServiceOptions{}),
+			purl:     "pkg:githubactions/actions/checkout@v4",
+			wantName: "actions/checkout",
+		},
+		{
+			name:     "docker hub",
+			service:  NewDockerHubService(DockerHubServiceOptions{}),
+			purl:     "pkg:docker/alpine@3.19",
+			wantName: "library/alpine",
+		},
+		{
+			name:     "hex",
+			service:  NewHexService(HexServiceOptions{}),
+			purl:     "pkg:hex/jason@1.4.1",
+			wantName: "jason",
+		},
+		{
+			name:     "pub",
+			service:  NewPubService(PubServiceOptions{}),
+			purl:     "pkg:pub/http@1.2.0",
+			wantName: "http",
+		},
+		{
+			name:     "packagist",
+			service:  NewPackagistService(PackagistServiceOptions{}),
+			purl:     "pkg:composer/monolog/monolog@3.5.0",
+			wantName: "monolog/monolog",
+		},
+		{
+			name:     "cpan",
+			service:  NewCPANService(CPANServiceOptions{}),
+			purl:     "pkg:cpan/Moose",
+			wantName: "Moose",
+		},
+		{
+			name:     "cran",
+			service:  NewCRANService(CRANServiceOptions{}),
+			purl:     "pkg:cran/jsonlite@1.8.8",
+			wantName: "jsonlite",
+		},
+		{
+			name:     "hackage",
+			service:  NewHackageService(HackageServiceOptions{}),
+			purl:     "pkg:hackage/aeson@2.2.1.0",
+			wantName: "aeson",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			start := time.Now()
+			got, err := tt.service.GetPackageInfo(ctx, purl)
+			t.Logf("GetPackageInfo(%s) took %s", tt.purl, time.Since(start))
+			if err != nil {
+				t.Fatalf("GetPackageInfo() error = %v", err)
+			}
+
+			if got.Name != tt.wantName {
+				t.Errorf("GetPackageInfo() Name = %q, want %q", got.Name, tt.wantName)
+			}
+			if got.Version == "" {
+				t.Error("GetPackageInfo() Version is empty")
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// registryPageTemplates returns the fmt templates of the registry web page of a package of the purl type,
+// without and with a version, or false if the registry of the type is unknown.
+//
+// Both templates take the package path; the versioned template also takes the version.
+// The versioned template is empty if the registry has no page per version.
+func registryPageTemplates(purlType string) (string, string, bool) {
+	switch purlType {
+	case packageurl.TypeNPM:
+		return "https://www.npmjs.com/package/%s", "https://www.npmjs.com/package/%s/v/%s", true
+	case packageurl.TypePyPi:
+		return "https://pypi.org/project/%s/", "https://pypi.org/project/%s/%s/", true
+	case packageurl.TypeCargo:
+		return "https://crates.io/crates/%s", "https://crates.io/crates/%s/%s", true
+	case packageurl.TypeGem:
+		return "https://rubygems.org/gems/%s", "https://rubygems.org/gems/%s/versions/%s", true
+	case packageurl.TypeNuget:
+		return "https://www.nuget.org/packages/%s", "https://www.nuget.org/packages/%s/%s", true
+	case packageurl.TypeMaven:
+		return "https://central.sonatype.com/artifact/%s", "https://central.sonatype.com/artifact/%s/%s", true
+	case packageurl.TypeGolang:
+		return "https://pkg.go.dev/%s", "https://pkg.go.dev/%s@%s", true
+	case packageurl.TypeHex:
+		return "https://hex.pm/packages/%s", "https://hex.pm/packages/%s/%s", true
+	case packageurl.TypePub:
+		return "https://pub.dev/packages/%s", "https://pub.dev/packages/%s/versions/%s", true
+	case packageurl.TypeComposer:
+		// Packagist has no page per version
+		return "https://packagist.org/packages/%s", "", true
+	default:
+		return "", "", false
+	}
+}
+
+// registryPageURL returns the URL of the web page of the package on its native registry,
+// or an empty string if the registry of the purl type is unknown.
+//
+// The URL is built from the purl rather than returned by the services, since not all of them know it.
+func registryPageURL(purl packageurl.PackageURL) string {
+	packageTemplate, versionTemplate, ok := registryPageTemplates(purl.Type)
+	if !ok || purl.Name == "" {
+		return ""
+	}
+
+	segments := strings.Split(purl.Namespace, "/")
+	if purl.Namespace == "" {
+		segments = nil
+	}
+	segments = append(segments, purl.Name)
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	packagePath := strings.Join(segments, "/")
+
+	if purl.Version == "" || versionTemplate == "" {
+		return fmt.Sprintf(packageTemplate, packagePath)
+	}
+	return fmt.Sprintf(versionTemplate, packagePath, url.PathEscape(purl.Version))
+}
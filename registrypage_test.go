@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// TestRegistryPageURL tests the registryPageURL function.
+func TestRegistryPageURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		purl string
+		want string
+	}{
+		{purl: "pkg:npm/lodash@4.17.21", want: "https://www.npmjs.com/package/lodash/v/4.17.21"},
+		{purl: "pkg:npm/lodash", want: "https://www.npmjs.com/package/lodash"},
+		{purl: "pkg:npm/%40types/node@18.0.0", want: "https://www.npmjs.com/package/@types/node/v/18.0.0"},
+		{purl: "pkg:pypi/requests@2.28.0", want: "https://pypi.org/project/requests/2.28.0/"},
+		{purl: "pkg:cargo/serde@1.0.188", want: "https://crates.io/crates/serde/1.0.188"},
+		{purl: "pkg:gem/rails@7.0.0", want: "https://rubygems.org/gems/rails/versions/7.0.0"},
+		{purl: "pkg:nuget/Newtonsoft.Json@13.0.3", want: "https://www.nuget.org/packages/Newtonsoft.Json/13.0.3"},
+		{
+			purl: "pkg:maven/org.apache.commons/commons-lang3@3.12.0",
+			want: "https://central.sonatype.com/artifact/org.apache.commons/commons-lang3/3.12.0",
+		},
+		{purl: "pkg:golang/golang.org/x/text@v0.13.0", want: "https://pkg.go.dev/golang.org/x/text@v0.13.0"},
+		{purl: "pkg:hex/phoenix@1.7.0", want: "https://hex.pm/packages/phoenix/1.7.0"},
+		{purl: "pkg:pub/http@1.1.0", want: "https://pub.dev/packages/http/versions/1.1.0"},
+		{purl: "pkg:composer/laravel/framework@v10.0.0", want: "https://packagist.org/packages/laravel/framework"},
+		{purl: "pkg:pypi/name%20with%20space", want: "https://pypi.org/project/name%20with%20space/"},
+		{purl: "pkg:generic/openssl@3.0.0", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.purl, func(t *testing.T) {
+			t.Parallel()
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			if got := registryPageURL(purl); got != tt.want {
+				t.Errorf("registryPageURL(%s) = %q, want %q", tt.purl, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/package-url/packageurl-go"
+)
+
+const (
+	// rubyGemsBaseURL is the base URL for the RubyGems API.
+	//
+	// See https://guides.rubygems.org/rubygems-org-api/
+	rubyGemsBaseURL = "https://rubygems.org"
+	// rubyGemsGemsAPIPath is the API path for gem information.
+	rubyGemsGemsAPIPath = "/api/v1/gems"
+	// rubyGemsVersionsAPIPath is the API path for gem versions.
+	rubyGemsVersionsAPIPath = "/api/v1/versions"
+)
+
+// RubyGemsService is the service for the RubyGems API.
+type RubyGemsService struct {
+	baseURL string
+	client  *http.Client
+}
+
+var _ Service = (*RubyGemsService)(nil)
+
+// RubyGemsServiceOptions are the options for the RubyGemsService.
+type RubyGemsServiceOptions struct {
+	// BaseURL is the base URL for the RubyGems API.
+	// If empty, defaults to the public RubyGems API.
+	BaseURL string
+	// Client is the HTTP client to use for the RubyGems API.
+	// If nil, defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// NewRubyGemsService creates a new RubyGemsService.
+func NewRubyGemsService(opts RubyGemsServiceOptions) *RubyGemsService {
+	// Default to the RubyGems API base URL.
+	baseURL := rubyGemsBaseURL
+	if opts.BaseURL != "" {
+		baseURL = opts.BaseURL
+	}
+	// Default to the default HTTP client.
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &RubyGemsService{
+		baseURL: baseURL,
+		client:  client,
+	}
+}
+
+// rubyGemsGemResponse is the response from the RubyGems gem endpoint.
+type rubyGemsGemResponse struct {
+	Name             string   `json:"name"`
+	Version          string   `json:"version"`
+	Licenses         []string `json:"licenses"`
+	Info             *string  `json:"info"`
+	HomepageURI      *string  `json:"homepage_uri"`
+	SourceCodeURI    *string  `json:"source_code_uri"`
+	DocumentationURI *string  `json:"documentation_uri"`
+	BugTrackerURI    *string  `json:"bug_tracker_uri"`
+	ChangelogURI     *string  `json:"changelog_uri"`
+}
+
+// rubyGemsVersionResponse is a single entry of the response from the RubyGems versions endpoint.
+type rubyGemsVersionResponse struct {
+	Number      string   `json:"number"`
+	Licenses    []string `json:"licenses"`
+	Description *string  `json:"description"`
+}
+
+// GetPackageInfo returns the information about a package.
+//
+// If the purl has a version, the version-specific licenses and description are used.
+func (s *RubyGemsService) GetPackageInfo(ctx context.Context, purl packageurl.PackageURL) (PackageInfo, error) {
+	if purl.Type != packageurl.TypeGem {
+		return PackageInfo{}, fmt.Errorf("%w: %s", ErrUnsupportedEcosystem, purl.Type)
+	}
+
+	gemURL := fmt.Sprintf("%s%s/%s.json", s.baseURL, rubyGemsGemsAPIPath, url.PathEscape(purl.Name))
+
+	var gem rubyGemsGemResponse
+	if err := getJSON(ctx, s.client, gemURL, nil, &gem); err != nil {
+		return PackageInfo{}, err
+	}
+
+	packageInfo := PackageInfo{
+		Name:             gem.Name,
+		Version:          gem.Version,
+		Licenses:         gem.Licenses,
+		Homepage:         stringValue(gem.HomepageURI),
+		RepositoryURL:    stringValue(gem.SourceCodeURI),
+		Description:      stringValue(gem.Info),
+		Ecosystem:        purl.Type,
+		DocumentationURL: stringValue(gem.DocumentationURI),
+		BugTrackerURL:    stringValue(gem.BugTrackerURI),
+		ChangelogURL:     stringValue(gem.ChangelogURI),
+	}
+
+	if purl.Version != "" && purl.Version != gem.Version {
+		versionInfo, err := s.getVersion(ctx, purl.Name, purl.Version)
+		if err != nil {
+			return PackageInfo{}, err
+		}
+		packageInfo.Version = versionInfo.Number
+		packageInfo.Licenses = versionInfo.Licenses
+		if versionInfo.Description != nil {
+			packageInfo.Description = *versionInfo.Description
+		}
+	}
+
+	if packageInfo.Licenses == nil {
+		packageInfo.Licenses = []string{}
+	}
+
+	return packageInfo, nil
+}
+
+// getVersion returns the data of a specific version of a gem.
+func (s *RubyGemsService) getVersion(ctx context.Context, name, version string) (rubyGemsVersionResponse, error) {
+	versionsURL := fmt.Sprintf("%s%s/%s.json", s.baseURL, rubyGemsVersionsAPIPath, url.PathEscape(name))
+
+	var versions []rubyGemsVersionResponse
+	if err := getJSON(ctx, s.client, versionsURL, nil, &versions); err != nil {
+		return rubyGemsVersionResponse{}, err
+	}
+
+	for _, v := range versions {
+		if v.Number == version {
+			return v, nil
+		}
+	}
+
+	return rubyGemsVersionResponse{}, fmt.Errorf("%w: %s version %s", ErrPackageNotFound, name, version)
+}
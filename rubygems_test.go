@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// rubyGemsTestGem is a canned response for the RubyGems gem endpoint.
+const rubyGemsTestGem = `{
+	"name": "rails",
+	"version": "7.1.3",
+	"licenses": ["MIT"],
+	"info": "Ruby on Rails is a full-stack web framework.",
+	"homepage_uri": "https://rubyonrails.org",
+	"source_code_uri": "https://github.com/rails/rails/tree/v7.1.3",
+	"documentation_uri": "https://api.rubyonrails.org/v7.1.3/",
+	"bug_tracker_uri": "https://github.com/rails/rails/issues",
+	"changelog_uri": "https://github.com/rails/rails/releases/tag/v7.1.3"
+}`
+
+// rubyGemsTestVersions is a canned response for the RubyGems versions endpoint.
+const rubyGemsTestVersions = `[
+	{"number": "7.1.3", "licenses": ["MIT"], "description": "Ruby on Rails is a full-stack web framework."},
+	{"number": "2.3.18", "licenses": null, "description": "Rails 2 description."}
+]`
+
+// TestNewRubyGemsService tests the NewRubyGemsService function.
+func TestNewRubyGemsService(t *testing.T) {
+	t.Parallel()
+
+	service := NewRubyGemsService(RubyGemsServiceOptions{})
+
+	if service.baseURL != rubyGemsBaseURL {
+		t.Errorf("baseURL = %q, want %q", service.baseURL, rubyGemsBaseURL)
+	}
+	if service.client != http.DefaultClient {
+		t.Error("client should be http.DefaultClient when not provided")
+	}
+}
+
+// TestRubyGemsService_GetPackageInfo tests the GetPackageInfo method.
+func TestRubyGemsService_GetPackageInfo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		purl    string
+		want    PackageInfo
+		wantErr error
+	}{
+		{
+			name: "without version",
+			purl: "pkg:gem/rails",
+			want: PackageInfo{
+				Name:             "rails",
+				Version:          "7.1.3",
+				Licenses:         []string{"MIT"},
+				Homepage:         "https://rubyonrails.org",
+				RepositoryURL:    "https://github.com/rails/rails/tree/v7.1.3",
+				Description:      "Ruby on Rails is a full-stack web framework.",
+				Ecosystem:        "gem",
+				DocumentationURL: "https://api.rubyonrails.org/v7.1.3/",
+				BugTrackerURL:    "https://github.com/rails/rails/issues",
+				ChangelogURL:     "https://github.com/rails/rails/releases/tag/v7.1.3",
+			},
+		},
+		{
+			name: "older version",
+			purl: "pkg:gem/rails@2.3.18",
+			want: PackageInfo{
+				Name:             "rails",
+				Version:          "2.3.18",
+				Licenses:         []string{},
+				Homepage:         "https://rubyonrails.org",
+				RepositoryURL:    "https://github.com/rails/rails/tree/v7.1.3",
+				Description:      "Rails 2 description.",
+				Ecosystem:        "gem",
+				DocumentationURL: "https://api.rubyonrails.org/v7.1.3/",
+				BugTrackerURL:    "https://github.com/rails/rails/issues",
+				ChangelogURL:     "https://github.com/rails/rails/releases/tag/v7.1.3",
+			},
+		},
+		{
+			name:    "unknown version",
+			purl:    "pkg:gem/rails@0.0.1",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name:    "unknown gem",
+			purl:    "pkg:gem/missing",
+			wantErr: ErrPackageNotFound,
+		},
+		{
+			name:    "unsupported ecosystem",
+			purl:    "pkg:npm/lodash@4.17.21",
+			wantErr: ErrUnsupportedEcosystem,
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/gems/rails.json", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(rubyGemsTestGem))
+	})
+	mux.HandleFunc("/api/v1/versions/rails.json", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(rubyGemsTestVersions))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	service := NewRubyGemsService(RubyGemsServiceOptions{
+		BaseURL: server.URL,
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			purl, err := packageurl.FromString(tt.purl)
+			if err != nil {
+				t.Fatalf("failed to parse purl: %v", err)
+			}
+
+			got, err := service.GetPackageInfo(context.Background(), purl)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetPackageInfo() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPackageInfo() unexpected error = %v", err)
+			}
+
+			if got.Name != tt.want.Name {
+				t.Errorf("GetPackageInfo() Name = %q, want %q", got.Name, tt.want.Name)
+			}
+			if got.Version != tt.want.Version {
+				t.Errorf("GetPackageInfo() Version = %q, want %q", got.Version, tt.want.Version)
+			}
+			if got.Ecosystem != tt.want.Ecosystem {
+				t.Errorf("GetPackageInfo() Ecosystem = %q, want %q", got.Ecosystem, tt.want.Ecosystem)
+			}
+			if got.Licenses == nil || !equalStringSlices(got.Licenses, tt.want.Licenses) {
+				t.Errorf("GetPackageInfo() Licenses = %#v, want %#v", got.Licenses, tt.want.Licenses)
+			}
+			if got.Homepage != tt.want.Homepage {
+				t.Errorf("GetPackageInfo() Homepage = %q, want %q", got.Homepage, tt.want.Homepage)
+			}
+			if got.RepositoryURL != tt.want.RepositoryURL {
+				t.Errorf("GetPackageInfo() RepositoryURL = %q, want %q", got.RepositoryURL, tt.want.RepositoryURL)
+			}
+			if got.Description != tt.want.Description {
+				t.Errorf("GetPackageInfo() Description = %q, want %q", got.Description, tt.want.Description)
+			}
+			if got.DocumentationURL != tt.want.DocumentationURL {
+				t.Errorf(
+					"GetPackageInfo() DocumentationURL = %q, want %q",
+					got.DocumentationURL,
+					tt.want.DocumentationURL,
+				)
+			}
+			if got.BugTrackerURL != tt.want.BugTrackerURL {
+				t.Errorf("GetPackageInfo() BugTrackerURL = %q, want %q", got.BugTrackerURL, tt.want.BugTrackerURL)
+			}
+			if got.ChangelogURL != tt.want.ChangelogURL {
+				t.Errorf("GetPackageInfo() ChangelogURL = %q, want %q", got.ChangelogURL, tt.want.ChangelogURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// scorecardBaseURL is the base URL for the OpenSSF Scorecard API.
+//
+// See https://api.securityscorecards.dev
+const scorecardBaseURL = "https://api.securityscorecards.dev"
+
+// ScorecardClient is the client for the OpenSSF Scorecard API.
+//
+// Unlike the services, it looks up repositories rather than packages: the repository of a package
+// comes from its RepositoryURL.
+type ScorecardClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+// ScorecardClientOptions are the options for the ScorecardClient.
+type ScorecardClientOptions struct {
+	// BaseURL is the base URL for the OpenSSF Scorecard API.
+	// If empty, defaults to api.securityscorecards.dev.
+	BaseURL string
+	// Client is the HTTP client to use for the OpenSSF Scorecard API.
+	// If nil, defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// NewScorecardClient creates a new ScorecardClient.
+func NewScorecardClient(opts ScorecardClientOptions) *ScorecardClient {
+	// Default to the OpenSSF Scorecard API base URL.
+	baseURL := scorecardBaseURL
+	if opts.BaseURL != "" {
+		baseURL = opts.BaseURL
+	}
+	// Default to the default HTTP client.
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &ScorecardClient{
+		baseURL: baseURL,
+		client:  client,
+	}
+}
+
+// scorecardResponse is the response from the Scorecard projects endpoint.
+type scorecardResponse struct {
+	// Date is the date of the Scorecard run (e.g., 2024-01-10).
+	Date string `json:"date"`
+	// Score is the aggregate score, from 0 to 10.
+	Score *float64 `json:"score"`
+}
+
+// GetScore returns the score and date of the latest Scorecard run of the project.
+//
+// The project is a repository path such as github.com/lodash/lodash (see forgeRepository).
+// ErrPackageNotFound is returned if the project was never scored.
+func (c *ScorecardClient) GetScore(ctx context.Context, project string) (float64, string, error) {
+	var result scorecardResponse
+	if err := getJSON(ctx, c.client, c.baseURL+"/projects/"+project, nil, &result); err != nil {
+		return 0, "", err
+	}
+	if result.Score == nil {
+		return 0, "", fmt.Errorf("%w: missing score", ErrInvalidResponse)
+	}
+
+	return *result.Score, result.Date, nil
+}
+
+// addScorecard sets the Scorecard score of the package info, looked up from its RepositoryURL.
+//
+// The score is supplementary: if it cannot be retrieved, a warning is written to cfg.Stderr
+// and the package info is returned unchanged.
+func addScorecard(ctx context.Context, cfg RunConfig, info PackageInfo) PackageInfo {
+	project, ok := forgeRepository(info.RepositoryURL)
+	if !ok {
+		cfg.Logger.Debug("no Scorecard project for the repository", "repository_url", info.RepositoryURL)
+		return info
+	}
+
+	cfg.Logger.Debug("fetching Scorecard score", "project", project)
+	score, date, err := cfg.Scorecard.GetScore(ctx, project)
+	if err != nil {
+		if errors.Is(err, ErrPackageNotFound) {
+			fmt.Fprintf(cfg.Stderr, "Warning: %s has no OpenSSF Scorecard\n", project)
+		} else {
+			fmt.Fprintf(cfg.Stderr, "Warning: Failed to get the OpenSSF Scorecard of %s: %v\n", project, err)
+		}
+		return info
+	}
+
+	info.ScorecardScore = &score
+	if date != "" {
+		info.ScorecardDate = &date
+	}
+	return info
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// scorecardTestResponse is a trimmed response from the Scorecard projects endpoint.
+const scorecardTestResponse = `{
+	"date": "2024-01-10",
+	"repo": {"name": "github.com/lodash/lodash", "commit": "f299b52f39486275a9e6483b60a410e06520c538"},
+	"scorecard": {"version": "v4.13.1", "commit": "49c0eed3a423f00c872b5c3c9f1bbca9e8aae799"},
+	"score": 7.8,
+	"checks": [{"name": "Maintained", "score": 10, "reason": "30 commit(s) found in the last 90 days"}]
+}`
+
+// TestScorecardClient_GetScore tests the GetScore method.
+func TestScorecardClient_GetScore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		response   string
+		wantScore  float64
+		wantDate   string
+		wantErr    error
+	}{
+		{
+			name:       "scored project",
+			statusCode: http.StatusOK,
+			response:   scorecardTestResponse,
+			wantScore:  7.8,
+			wantDate:   "2024-01-10",
+		},
+		{
+			name:       "project never scored",
+			statusCode: http.StatusNotFound,
+			response:   `{"code":404,"message":"no results for this repo"}`,
+			wantErr:    ErrPackageNotFound,
+		},
+		{
+			name:       "missing score",
+			statusCode: http.StatusOK,
+			response:   `{"date":"2024-01-10"}`,
+			wantErr:    ErrInvalidResponse,
+		},
+		{
+			name:       "server error",
+			statusCode: http.StatusInternalServerError,
+			wantErr:    ErrAPIError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/projects/github.com/lodash/lodash" {
+					t.Errorf("request path = %q, want /projects/github.com/lodash/lodash", r.URL.Path)
+				}
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			t.Cleanup(server.Close)
+
+			client := NewScorecardClient(ScorecardClientOptions{BaseURL: server.URL})
+
+			score, date, err := client.GetScore(context.Background(), "github.com/lodash/lodash")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetScore() error = %v, want %v", err, tt.wantErr)
+			}
+			if score != tt.wantScore || date != tt.wantDate {
+				t.Errorf("GetScore() = %v, %q, want %v, %q", score, date, tt.wantScore, tt.wantDate)
+			}
+		})
+	}
+}
+
+// TestAddScorecard tests the addScorecard function.
+func TestAddScorecard(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		repositoryURL string
+		statusCode    int
+		wantScore     bool
+		wantWarning   string
+	}{
+		{
+			name:          "scored repository",
+			repositoryURL: "https://github.com/lodash/lodash",
+			statusCode:    http.StatusOK,
+			wantScore:     true,
+		},
+		{
+			name:          "repository never scored",
+			repositoryURL: "https://github.com/lodash/lodash",
+			statusCode:    http.StatusNotFound,
+			wantWarning:   "Warning: github.com/lodash/lodash has no OpenSSF Scorecard\n",
+		},
+		{
+			name:          "API error",
+			repositoryURL: "https://github.com/lodash/lodash",
+			statusCode:    http.StatusServiceUnavailable,
+			wantWarning:   "Warning: Failed to get the OpenSSF Scorecard of github.com/lodash/lodash",
+		},
+		{
+			name: "no repository",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(scorecardTestResponse))
+			}))
+			t.Cleanup(server.Close)
+
+			cfg, _, stderr := newTestRunConfig(false, formatText)
+			cfg.Scorecard = NewScorecardClient(ScorecardClientOptions{BaseURL: server.URL})
+
+			info := addScorecard(context.Background(), cfg, PackageInfo{Name: "lodash", RepositoryURL: tt.repositoryURL})
+
+			if got := info.ScorecardScore != nil; got != tt.wantScore {
+				t.Fatalf("addScorecard() score set = %v, want %v", got, tt.wantScore)
+			}
+			if tt.wantScore {
+				if *info.ScorecardScore != 7.8 || info.ScorecardDate == nil || *info.ScorecardDate != "2024-01-10" {
+					t.Errorf("addScorecard() score = %v, date = %v, want 7.8, 2024-01-10",
+						*info.ScorecardScore, info.ScorecardDate)
+				}
+			}
+			if !strings.HasPrefix(stderr.String(), tt.wantWarning) || (tt.wantWarning == "" && stderr.Len() != 0) {
+				t.Errorf("addScorecard() stderr = %q, want prefix %q", stderr.String(), tt.wantWarning)
+			}
+		})
+	}
+}
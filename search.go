@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+)
+
+const (
+	// searchCommand is the name of the search subcommand.
+	searchCommand = "search"
+	// defaultSearchLimit is the default maximum number of search results.
+	defaultSearchLimit = 10
+)
+
+// runSearch runs the search subcommand with its arguments.
+// The service must implement PackageSearcher.
+func runSearch(cfg RunConfig, service Service, args []string) int {
+	flags := flag.NewFlagSet(searchCommand, flag.ContinueOnError)
+	flags.SetOutput(cfg.Stderr)
+	ecosystem := flags.String("ecosystem", "", "Only return packages of this ecosystem (e.g., npm)")
+	limit := flags.Int("limit", defaultSearchLimit, "Maximum number of results")
+	flags.Usage = func() {
+		fmt.Fprintf(cfg.Stderr, "Usage: %s [OPTIONS] search [SEARCH OPTIONS] query\n\n", os.Args[0])
+		fmt.Fprintf(cfg.Stderr, "Search for packages.\n\n")
+		fmt.Fprintf(cfg.Stderr, "Search options:\n")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return exitInvalidArgs
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintf(cfg.Stderr, "Error: Expected 1 search query, got %d\n\n", flags.NArg())
+		flags.Usage()
+		return exitInvalidArgs
+	}
+	query := flags.Arg(0)
+	// The API would treat a limit of 0 as no limit at all
+	if *limit < 1 {
+		fmt.Fprintf(cfg.Stderr, "Error: -limit must be at least 1, got %d\n\n", *limit)
+		flags.Usage()
+		return exitInvalidPurl
+	}
+
+	searcher, ok := service.(PackageSearcher)
+	if !ok {
+		fmt.Fprintf(cfg.Stderr, "Error: The selected backend does not support searching\n")
+		return exitInvalidArgs
+	}
+
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	cfg.Logger.Debug("searching packages", "query", query, "ecosystem", *ecosystem, "limit", *limit)
+	results, err := searcher.SearchPackages(ctx, query, *ecosystem, *limit)
+	if err != nil {
+		return commandError(cfg, "Failed to search packages", err)
+	}
+
+	if printErr := printPackageList(cfg.Stdout, results, cfg.Format); printErr != nil {
+		fmt.Fprintf(cfg.Stderr, "Error: %v\n", printErr)
+		return exitRuntimeError
+	}
+
+	return exitSuccess
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// mockSearcher is a mock implementation of the Service and PackageSearcher interfaces for testing.
+type mockSearcher struct {
+	mockService
+
+	results   []PackageInfo
+	gotQuery  string
+	gotEco    string
+	gotLimit  int
+	searchErr error
+}
+
+func (m *mockSearcher) SearchPackages(_ context.Context, query, ecosystem string, limit int) ([]PackageInfo, error) {
+	m.gotQuery, m.gotEco, m.gotLimit = query, ecosystem, limit
+	return m.results, m.searchErr
+}
+
+// TestRunSearch tests the runSearch function.
+func TestRunSearch(t *testing.T) {
+	t.Parallel()
+
+	results := []PackageInfo{
+		{Name: "lodash", Version: "4.17.21", Licenses: []string{"MIT"}, Ecosystem: "npm"},
+		{Name: "lodash-es", Version: "4.17.21", Licenses: []string{"MIT"}, Ecosystem: "npm"},
+	}
+
+	tests := []struct {
+		name       string
+		service    Service
+		args       []string
+		format     string
+		want       int
+		wantStdout []string
+		wantQuery  string
+		wantEco    string
+		wantLimit  int
+	}{
+		{
+			name:       "human-readable output",
+			service:    &mockSearcher{results: results},
+			args:       []string{"lodash"},
+			format:     formatText,
+			want:       exitSuccess,
+			wantStdout: []string{"Name:             lodash", "Name:             lodash-es"},
+			wantQuery:  "lodash",
+			wantLimit:  defaultSearchLimit,
+		},
+		{
+			name:       "JSON output with options",
+			service:    &mockSearcher{results: results},
+			args:       []string{"-ecosystem", "npm", "-limit", "2", "lodash"},
+			format:     formatJSON,
+			want:       exitSuccess,
+			wantStdout: []string{`"name": "lodash"`, `"name": "lodash-es"`},
+			wantQuery:  "lodash",
+			wantEco:    "npm",
+			wantLimit:  2,
+		},
+		{
+			name:    "missing query",
+			service: &mockSearcher{},
+			args:    []string{},
+			want:    exitInvalidArgs,
+		},
+		{
+			name:    "unknown option",
+			service: &mockSearcher{},
+			args:    []string{"-unknown", "lodash"},
+			want:    exitInvalidArgs,
+		},
+		{
+			name:    "zero limit",
+			service: &mockSearcher{},
+			args:    []string{"-limit", "0", "lodash"},
+			want:    exitInvalidPurl,
+		},
+		{
+			name:    "negative limit",
+			service: &mockSearcher{},
+			args:    []string{"-limit", "-5", "lodash"},
+			want:    exitInvalidPurl,
+		},
+		{
+			name:    "search error",
+			service: &mockSearcher{searchErr: ErrInvalidResponse},
+			args:    []string{"lodash"},
+			want:    exitAPIError,
+		},
+		{
+			name:    "unsupported service",
+			service: &mockService{},
+			args:    []string{"lodash"},
+			want:    exitInvalidArgs,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, stdout, _ := newTestRunConfig(false, tt.format)
+
+			exitCode := runSearch(cfg, tt.service, tt.args)
+
+			if exitCode != tt.want {
+				t.Errorf("runSearch() = %d, want %d", exitCode, tt.want)
+			}
+
+			output := stdout.String()
+			for _, want := range tt.wantStdout {
+				if !strings.Contains(output, want) {
+					t.Errorf("runSearch() output missing %q\nGot: %s", want, output)
+				}
+			}
+
+			if tt.format == formatJSON {
+				var got []PackageInfo
+				if jsonErr := json.Unmarshal(stdout.Bytes(), &got); jsonErr != nil {
+					t.Errorf("runSearch() produced invalid JSON: %v\nOutput: %s", jsonErr, output)
+				}
+			}
+
+			if searcher, ok := tt.service.(*mockSearcher); ok && tt.want == exitSuccess {
+				if searcher.gotQuery != tt.wantQuery || searcher.gotEco != tt.wantEco || searcher.gotLimit != tt.wantLimit {
+					t.Errorf(
+						"SearchPackages() called with (%q, %q, %d), want (%q, %q, %d)",
+						searcher.gotQuery, searcher.gotEco, searcher.gotLimit,
+						tt.wantQuery, tt.wantEco, tt.wantLimit,
+					)
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/package-url/packageurl-go"
 )
@@ -12,6 +13,14 @@ var (
 	ErrPackageNotFound = errors.New("package not found")
 	// ErrInvalidResponse is returned when the API response is invalid.
 	ErrInvalidResponse = errors.New("invalid API response")
+	// ErrUnsupportedEcosystem is returned when a service does not support the purl type.
+	ErrUnsupportedEcosystem = errors.New("unsupported ecosystem")
+	// ErrRateLimited is returned when the API rejects a request because of rate limiting.
+	ErrRateLimited = errors.New("rate limited by API")
+	// ErrAPIError is returned when the API responds with an unexpected HTTP status.
+	ErrAPIError = errors.New("API error")
+	// ErrInvalidPURL is returned when a purl is missing a component the service requires.
+	ErrInvalidPURL = errors.New("invalid purl")
 )
 
 // PackageInfo represents the information about a package.
@@ -19,21 +28,86 @@ var (
 // Each service should return this information.
 type PackageInfo struct {
 	// The name of the package.
-	Name string `json:"name"`
+	Name string `json:"name" toml:"name"`
 	// The version of the package.
-	Version string `json:"version"`
+	Version string `json:"version" toml:"version"`
 	// The licenses of the package.
-	Licenses []string `json:"licenses"`
+	//
+	// Services return an empty slice rather than nil if the package has no licenses, so that the JSON
+	// output is always an array; completePackageInfo enforces this for services that do not.
+	Licenses []string `json:"licenses" toml:"licenses"`
+	// The licenses combined into a single SPDX expression (empty string if there are no licenses).
+	//
+	// This is computed by purlinfo rather than returned by the services.
+	LicenseSPDXExpression string `json:"license_spdx_expression,omitempty" toml:"license_spdx_expression,omitempty"`
+	// Whether the licenses are not SPDX identifiers, such as R license specifications, in which case
+	// LicenseSPDXExpression is left empty.
+	//
+	// This is set by the services that return such licenses and is not part of the output.
+	NonSPDXLicenses bool `json:"-" toml:"-"`
 	// The homepage URL of the package (empty string if not available).
-	Homepage string `json:"homepage,omitempty"`
+	Homepage string `json:"homepage,omitempty" toml:"homepage,omitempty"`
 	// The repository URL of the package (empty string if not available).
-	RepositoryURL string `json:"repository_url,omitempty"`
+	RepositoryURL string `json:"repository_url,omitempty" toml:"repository_url,omitempty"`
 	// The description of the package (empty string if not available).
-	Description string `json:"description,omitempty"`
+	Description string `json:"description,omitempty" toml:"description,omitempty"`
 	// The ecosystem/type of the package (e.g., npm, pypi, cargo).
-	Ecosystem string `json:"ecosystem"`
+	Ecosystem string `json:"ecosystem" toml:"ecosystem"`
 	// The documentation URL of the package (empty string if not available).
-	DocumentationURL string `json:"documentation_url,omitempty"`
+	DocumentationURL string `json:"documentation_url,omitempty" toml:"documentation_url,omitempty"`
+	// The URL of the issue tracker of the package (empty string if not available).
+	BugTrackerURL string `json:"bug_tracker_url,omitempty" toml:"bug_tracker_url,omitempty"`
+	// The URL of the changelog of the package (empty string if not available).
+	//
+	// If the service does not return one, purlinfo uses the releases page of a GitHub or GitLab repository
+	// (see releasesPageURL).
+	ChangelogURL string `json:"changelog_url,omitempty" toml:"changelog_url,omitempty"`
+	// The URL of the security policy of the package (empty string if not available).
+	//
+	// This is computed by purlinfo, from a GitHub RepositoryURL (see securityPolicyURL).
+	SecurityPolicyURL string `json:"security_policy_url,omitempty" toml:"security_policy_url,omitempty"`
+	// The URL of the package page on its native registry (empty string if not known).
+	//
+	// If the service does not return one, purlinfo computes it from the purl (see registryPageURL),
+	// unless the package is on a private registry.
+	RegistryURL string `json:"registry_url,omitempty" toml:"registry_url,omitempty"`
+	// Whether the package is hosted on a private registry, such as GitHub Packages, rather than on the
+	// public registry of its ecosystem, in which case RegistryURL is not computed from the purl.
+	//
+	// This is set by the services of such registries and is not part of the output.
+	PrivateRegistry bool `json:"-" toml:"-"`
+	// The purl string that was looked up, for correlating output with input.
+	//
+	// Like LicenseSPDXExpression, this is set by purlinfo rather than by the services.
+	OriginalPURL string `json:"original_purl" toml:"original_purl"`
+	// The purl with the version resolved, if the purl that was looked up had no version or the version "latest"
+	// (empty string otherwise).
+	//
+	// Like LicenseSPDXExpression, this is set by purlinfo rather than by the services.
+	ResolvedPURL string `json:"resolved_purl,omitempty" toml:"resolved_purl,omitempty"`
+	// The number of direct dependencies of the package (nil if not available).
+	DependencyCount *int `json:"dependency_count,omitempty" toml:"dependency_count,omitempty"`
+	// The number of contributors to the repository of the package (nil if not available).
+	ContributorCount *int `json:"contributor_count,omitempty" toml:"contributor_count,omitempty"`
+	// The time of the last commit pushed to the repository of the package (nil if not available).
+	LastCommitDate *time.Time `json:"last_commit_date,omitempty" toml:"last_commit_date,omitempty"`
+	// The number of forks of the repository of the package (nil if not available).
+	ForksCount *int `json:"forks_count,omitempty" toml:"forks_count,omitempty"`
+	// The number of watchers of the repository of the package (nil if not available).
+	WatchersCount *int `json:"watchers_count,omitempty" toml:"watchers_count,omitempty"`
+	// The number of downloads of the package from its registry, such as the pulls of a container image
+	// (nil if not available).
+	DownloadCount *int `json:"download_count,omitempty" toml:"download_count,omitempty"`
+	// The time the version was last published to the registry, or the time of the last publication
+	// of the package if the purl has no version (nil if not available).
+	LastPublishedDate *time.Time `json:"last_published_date,omitempty" toml:"last_published_date,omitempty"`
+	// The OpenSSF Scorecard score of the repository of the package, from 0 to 10 (nil if not requested
+	// or not available).
+	//
+	// This is looked up by purlinfo with -scorecard rather than returned by the services.
+	ScorecardScore *float64 `json:"scorecard_score,omitempty" toml:"scorecard_score,omitempty"`
+	// The date of the Scorecard run the score comes from (nil if not available).
+	ScorecardDate *string `json:"scorecard_date,omitempty" toml:"scorecard_date,omitempty"`
 }
 
 // Service is the interface that each service must implement.
@@ -41,3 +115,30 @@ type Service interface {
 	// GetPackageInfo returns the information about a package.
 	GetPackageInfo(ctx context.Context, purl packageurl.PackageURL) (PackageInfo, error)
 }
+
+// VersionLister is the interface implemented by services that can list the versions of a package.
+//
+// It is separate from Service so that adding it does not break existing implementations;
+// callers should use a type assertion to check for support.
+type VersionLister interface {
+	// ListVersions returns all available versions of a package, sorted from oldest to newest.
+	ListVersions(ctx context.Context, purl packageurl.PackageURL) ([]string, error)
+}
+
+// PackageSearcher is the interface implemented by services that can search for packages.
+//
+// Like VersionLister, it is separate from Service; callers should use a type assertion.
+type PackageSearcher interface {
+	// SearchPackages returns up to limit packages matching the query.
+	// If ecosystem is not empty, only packages of that ecosystem are returned.
+	SearchPackages(ctx context.Context, query string, ecosystem string, limit int) ([]PackageInfo, error)
+}
+
+// MatchLister is the interface implemented by services whose lookups can match several packages,
+// for example a package mirrored in several registries.
+//
+// Like VersionLister, it is separate from Service; callers should use a type assertion.
+type MatchLister interface {
+	// ListMatches returns the information about every package matching the purl.
+	ListMatches(ctx context.Context, purl packageurl.PackageURL) ([]PackageInfo, error)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// licenseOperatorAnd combines licenses with the SPDX AND operator (all licenses apply).
+	licenseOperatorAnd = "and"
+	// licenseOperatorOr combines licenses with the SPDX OR operator (any license may be chosen).
+	licenseOperatorOr = "or"
+)
+
+// parseLicenseOperator converts the -license-operator flag value into an SPDX operator.
+func parseLicenseOperator(value string) (string, error) {
+	switch strings.ToLower(value) {
+	case licenseOperatorAnd:
+		return "AND", nil
+	case licenseOperatorOr:
+		return "OR", nil
+	default:
+		return "", fmt.Errorf("invalid license operator %q (expected %s or %s)",
+			value, licenseOperatorAnd, licenseOperatorOr)
+	}
+}
+
+// spdxExpression combines licenses into a single SPDX license expression joined by operator.
+//
+// A single license is returned unchanged. Licenses that are themselves compound expressions
+// (e.g., "MIT OR Apache-2.0") are parenthesized so the combined expression keeps its meaning.
+func spdxExpression(licenses []string, operator string) string {
+	if len(licenses) == 1 {
+		return licenses[0]
+	}
+
+	terms := make([]string, 0, len(licenses))
+	for _, license := range licenses {
+		if strings.Contains(license, " ") {
+			license = "(" + license + ")"
+		}
+		terms = append(terms, license)
+	}
+	return strings.Join(terms, " "+operator+" ")
+}
+
+// canonicalSPDXLicenseID returns the SPDX identifier of a license in its canonical case, for registries
+// that report lowercase identifiers (e.g., "bsd-3-clause" becomes "BSD-3-Clause").
+//
+// SPDX identifiers are matched case-insensitively, so identifiers not in the list of common licenses
+// below are returned unchanged.
+func canonicalSPDXLicenseID(license string) string {
+	for _, id := range []string{
+		"0BSD", "AFL-3.0", "AGPL-3.0", "AGPL-3.0-only", "AGPL-3.0-or-later", "Apache-2.0", "Artistic-2.0",
+		"BlueOak-1.0.0", "BSD-1-Clause", "BSD-2-Clause", "BSD-3-Clause", "BSD-3-Clause-Clear", "BSD-4-Clause",
+		"BSL-1.0", "CC-BY-4.0", "CC-BY-SA-4.0", "CC0-1.0", "CDDL-1.0", "ECL-2.0", "EPL-1.0", "EPL-2.0",
+		"EUPL-1.1", "EUPL-1.2", "GPL-2.0", "GPL-2.0-only", "GPL-2.0-or-later", "GPL-3.0", "GPL-3.0-only",
+		"GPL-3.0-or-later", "ISC", "LGPL-2.1", "LGPL-2.1-only", "LGPL-2.1-or-later", "LGPL-3.0", "LGPL-3.0-only",
+		"LGPL-3.0-or-later", "MIT", "MIT-0", "MPL-2.0", "MS-PL", "MS-RL", "NCSA", "OFL-1.1", "OSL-3.0",
+		"PostgreSQL", "Unicode-DFS-2016", "Unlicense", "UPL-1.0", "W3C", "WTFPL", "Zlib",
+	} {
+		if strings.EqualFold(license, id) {
+			return id
+		}
+	}
+	return license
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"testing"
+)
+
+// TestParseLicenseOperator tests the parseLicenseOperator function.
+func TestParseLicenseOperator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "and", want: "AND"},
+		{value: "or", want: "OR"},
+		{value: "OR", want: "OR"},
+		{value: "xor", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseLicenseOperator(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLicenseOperator(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseLicenseOperator(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSPDXExpression tests the spdxExpression function.
+func TestSPDXExpression(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		licenses []string
+		operator string
+		want     string
+	}{
+		{name: "no licenses", licenses: []string{}, operator: "AND", want: ""},
+		{name: "single license", licenses: []string{"MIT"}, operator: "AND", want: "MIT"},
+		{
+			name:     "single compound license",
+			licenses: []string{"MIT OR Apache-2.0"},
+			operator: "AND",
+			want:     "MIT OR Apache-2.0",
+		},
+		{
+			name:     "multiple licenses with AND",
+			licenses: []string{"MIT", "Apache-2.0"},
+			operator: "AND",
+			want:     "MIT AND Apache-2.0",
+		},
+		{
+			name:     "multiple licenses with OR",
+			licenses: []string{"MIT", "Apache-2.0", "BSD-3-Clause"},
+			operator: "OR",
+			want:     "MIT OR Apache-2.0 OR BSD-3-Clause",
+		},
+		{
+			name:     "compound license is parenthesized",
+			licenses: []string{"MIT OR Apache-2.0", "BSD-3-Clause"},
+			operator: "AND",
+			want:     "(MIT OR Apache-2.0) AND BSD-3-Clause",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := spdxExpression(tt.licenses, tt.operator); got != tt.want {
+				t.Errorf("spdxExpression(%v, %q) = %q, want %q", tt.licenses, tt.operator, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCanonicalSPDXLicenseID tests the canonicalSPDXLicenseID function.
+func TestCanonicalSPDXLicenseID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		license string
+		want    string
+	}{
+		{license: "mit", want: "MIT"},
+		{license: "bsd-3-clause", want: "BSD-3-Clause"},
+		{license: "apache-2.0", want: "Apache-2.0"},
+		{license: "gpl-3.0-or-later", want: "GPL-3.0-or-later"},
+		{license: "MPL-2.0", want: "MPL-2.0"},
+		{license: "x11-distribute-modifications-variant", want: "x11-distribute-modifications-variant"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.license, func(t *testing.T) {
+			t.Parallel()
+
+			if got := canonicalSPDXLicenseID(tt.license); got != tt.want {
+				t.Errorf("canonicalSPDXLicenseID(%q) = %q, want %q", tt.license, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/BurntSushi/toml"
+)
+
+// tomlPackageList is the TOML document of a list of packages, since a TOML document must be a table.
+type tomlPackageList struct {
+	// Packages are the packages, encoded as an array of tables.
+	Packages []PackageInfo `toml:"packages"`
+}
+
+// printTOMLOutput prints the package info to w as a TOML document.
+//
+// The keys are the same as in the JSON output. Empty optional fields and nil pointer fields are omitted,
+// since TOML has no null.
+func printTOMLOutput(w io.Writer, info PackageInfo) error {
+	if err := toml.NewEncoder(w).Encode(info); err != nil {
+		return fmt.Errorf("failed to encode TOML: %w", err)
+	}
+	return nil
+}
+
+// printTOMLPackageList prints a list of packages to w as a TOML document, with one [[packages]] table
+// per package.
+func printTOMLPackageList(w io.Writer, results []PackageInfo) error {
+	encoder := toml.NewEncoder(w)
+	encoder.Indent = ""
+	if err := encoder.Encode(tomlPackageList{Packages: results}); err != nil {
+		return fmt.Errorf("failed to encode TOML: %w", err)
+	}
+	return nil
+}
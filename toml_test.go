@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestPrintTOMLOutput tests the printTOMLOutput function.
+func TestPrintTOMLOutput(t *testing.T) {
+	t.Parallel()
+
+	dependencyCount := 3
+	score := 7.5
+
+	tests := []struct {
+		name string
+		info PackageInfo
+		want string
+	}{
+		{
+			name: "all fields",
+			info: PackageInfo{
+				Name:            "lodash",
+				Version:         "4.17.21",
+				Licenses:        []string{"MIT", "Apache-2.0"},
+				Description:     "Lodash \"modular\" utilities",
+				Ecosystem:       "npm",
+				OriginalPURL:    "pkg:npm/lodash@4.17.21",
+				DependencyCount: &dependencyCount,
+				ScorecardScore:  &score,
+			},
+			want: `name = "lodash"
+version = "4.17.21"
+licenses = ["MIT", "Apache-2.0"]
+description = "Lodash \"modular\" utilities"
+ecosystem = "npm"
+original_purl = "pkg:npm/lodash@4.17.21"
+dependency_count = 3
+scorecard_score = 7.5
+`,
+		},
+		{
+			name: "nil pointers and empty optional fields omitted",
+			info: PackageInfo{Name: "lodash", Version: "4.17.21", Licenses: []string{}, Ecosystem: "npm"},
+			want: `name = "lodash"
+version = "4.17.21"
+licenses = []
+ecosystem = "npm"
+original_purl = ""
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			if err := printTOMLOutput(&buf, tt.info); err != nil {
+				t.Fatalf("printTOMLOutput() unexpected error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("printTOMLOutput() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPrintTOMLPackageList tests the printTOMLPackageList function.
+func TestPrintTOMLPackageList(t *testing.T) {
+	t.Parallel()
+
+	results := []PackageInfo{
+		{Name: "lodash", Version: "4.17.21", Licenses: []string{"MIT"}, Ecosystem: "npm"},
+		{Name: "express", Version: "4.18.2", Licenses: []string{"MIT"}, Ecosystem: "npm"},
+	}
+
+	var buf bytes.Buffer
+	if err := printTOMLPackageList(&buf, results); err != nil {
+		t.Fatalf("printTOMLPackageList() unexpected error = %v", err)
+	}
+
+	want := `[[packages]]
+name = "lodash"
+version = "4.17.21"
+licenses = ["MIT"]
+ecosystem = "npm"
+original_purl = ""
+
+[[packages]]
+name = "express"
+version = "4.18.2"
+licenses = ["MIT"]
+ecosystem = "npm"
+original_purl = ""
+`
+	if got := buf.String(); got != want {
+		t.Errorf("printTOMLPackageList() =\n%s\nwant\n%s", got, want)
+	}
+}
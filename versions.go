@@ -0,0 +1,104 @@
+package main
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// sortVersions sorts version strings from oldest to newest using compareVersions.
+func sortVersions(versions []string) {
+	slices.SortStableFunc(versions, compareVersions)
+}
+
+// compareVersions compares two version strings.
+//
+// Versions are split into runs of digits and runs of letters; digit runs are compared
+// numerically and letter runs lexically, so "1.10.0" sorts after "1.9.0" and "1.0.0-rc1"
+// sorts before "1.0.0". This is not a full semver implementation, but orders the common
+// version schemes sensibly.
+func compareVersions(a, b string) int {
+	partsA := splitVersion(a)
+	partsB := splitVersion(b)
+
+	for i := range min(len(partsA), len(partsB)) {
+		if c := compareVersionParts(partsA[i], partsB[i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(partsA) > len(partsB):
+		return extraPartsOrder(partsA[len(partsB)])
+	case len(partsA) < len(partsB):
+		return -extraPartsOrder(partsB[len(partsA)])
+	default:
+		return 0
+	}
+}
+
+// extraPartsOrder returns how a version with extra parts compares to the same version without them.
+//
+// Extra numbers make a version newer ("1.0.0.1"), extra letters make it a pre-release ("1.0.0-beta").
+func extraPartsOrder(part string) int {
+	if isNumeric(part) {
+		return 1
+	}
+	return -1
+}
+
+// compareVersionParts compares two parts of a version string.
+func compareVersionParts(a, b string) int {
+	switch {
+	case isNumeric(a) && isNumeric(b):
+		numA, _ := strconv.ParseUint(a, 10, 64)
+		numB, _ := strconv.ParseUint(b, 10, 64)
+		switch {
+		case numA < numB:
+			return -1
+		case numA > numB:
+			return 1
+		default:
+			return 0
+		}
+	case isNumeric(a):
+		// A release number is newer than a pre-release label at the same position.
+		return 1
+	case isNumeric(b):
+		return -1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// isNumeric reports whether a version part is a run of digits.
+func isNumeric(part string) bool {
+	return part != "" && unicode.IsDigit(rune(part[0]))
+}
+
+// splitVersion splits a version into runs of digits and runs of letters, dropping separators.
+func splitVersion(version string) []string {
+	var parts []string
+	start := -1
+	for i, r := range version {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				parts = append(parts, version[start:i])
+				start = -1
+			}
+			continue
+		}
+		if start >= 0 && unicode.IsDigit(r) != unicode.IsDigit(rune(version[start])) {
+			parts = append(parts, version[start:i])
+			start = -1
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		parts = append(parts, version[start:])
+	}
+	return parts
+}
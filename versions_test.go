@@ -0,0 +1,66 @@
+package main
+
+import (
+	"testing"
+)
+
+// TestCompareVersions tests the compareVersions function.
+func TestCompareVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a    string
+		b    string
+		want int
+	}{
+		{a: "1.0.0", b: "1.0.0", want: 0},
+		{a: "1.9.0", b: "1.10.0", want: -1},
+		{a: "2.0.0", b: "1.99.99", want: 1},
+		{a: "1.0.0-rc1", b: "1.0.0", want: -1},
+		{a: "1.0.0", b: "1.0.0.1", want: -1},
+		{a: "1.0.0-alpha", b: "1.0.0-beta", want: -1},
+		{a: "1.0.0-rc.2", b: "1.0.0-rc.10", want: -1},
+		{a: "v1.2.3", b: "v1.2.4", want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_vs_"+tt.b, func(t *testing.T) {
+			t.Parallel()
+
+			got := compareVersions(tt.a, tt.b)
+			if sign(got) != tt.want {
+				t.Errorf("compareVersions(%q, %q) = %d, want sign %d", tt.a, tt.b, got, tt.want)
+			}
+			// The comparison must be antisymmetric.
+			if sign(compareVersions(tt.b, tt.a)) != -tt.want {
+				t.Errorf("compareVersions(%q, %q) is not antisymmetric", tt.b, tt.a)
+			}
+		})
+	}
+}
+
+// TestSortVersions tests the sortVersions function.
+func TestSortVersions(t *testing.T) {
+	t.Parallel()
+
+	versions := []string{"1.10.0", "1.2.0", "1.0.0", "1.0.0-rc1", "0.9.1", "1.2.0-beta"}
+	want := []string{"0.9.1", "1.0.0-rc1", "1.0.0", "1.2.0-beta", "1.2.0", "1.10.0"}
+
+	sortVersions(versions)
+
+	if !equalStringSlices(versions, want) {
+		t.Errorf("sortVersions() = %v, want %v", versions, want)
+	}
+}
+
+// sign returns -1, 0 or 1 depending on the sign of n.
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}